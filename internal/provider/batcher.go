@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	batchOpCreate = "create"
+	batchOpUpdate = "update"
+)
+
+// secretBatcher coalesces single secret creates and updates for the same app
+// and environment that arrive within window into one batch request. Each
+// caller still receives only the result for its own secret.
+type secretBatcher struct {
+	client *PhaseClient
+	window time.Duration
+
+	mu      sync.Mutex
+	pending map[batchKey]*pendingBatch
+}
+
+// batchKey identifies the requests that can share a batch
+type batchKey struct {
+	op    string
+	appID string
+	env   string
+}
+
+// pendingBatch holds the secrets waiting to be sent in one batch request
+type pendingBatch struct {
+	ctx     context.Context
+	secrets []Secret
+	results []chan batchResult
+}
+
+// batchResult is the outcome of one secret in a batch request
+type batchResult struct {
+	secret *Secret
+	err    error
+}
+
+// newSecretBatcher creates a batcher that sends requests through client
+func newSecretBatcher(client *PhaseClient, window time.Duration) *secretBatcher {
+	return &secretBatcher{
+		client:  client,
+		window:  window,
+		pending: make(map[batchKey]*pendingBatch),
+	}
+}
+
+// Submit adds a secret to the pending batch for its app, environment and
+// operation and waits for the batch to be sent
+func (b *secretBatcher) Submit(ctx context.Context, op, appID, env string, secret Secret) (*Secret, error) {
+	key := batchKey{op: op, appID: appID, env: env}
+	result := make(chan batchResult, 1)
+
+	b.mu.Lock()
+	batch, ok := b.pending[key]
+	if ok && batch.contains(op, secret) {
+		// The same secret twice in one request is ambiguous, so send it alone
+		b.mu.Unlock()
+		return b.sendOne(ctx, op, appID, env, secret)
+	}
+	if !ok {
+		// Later callers may be cancelled independently of the first one
+		batch = &pendingBatch{ctx: context.WithoutCancel(ctx)}
+		b.pending[key] = batch
+		time.AfterFunc(b.window, func() { b.flush(key) })
+	}
+	batch.secrets = append(batch.secrets, secret)
+	batch.results = append(batch.results, result)
+	b.mu.Unlock()
+
+	select {
+	case r := <-result:
+		return r.secret, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// contains reports whether the batch already holds the same secret, by ID for
+// updates and by key and path for creates
+func (p *pendingBatch) contains(op string, secret Secret) bool {
+	for _, pending := range p.secrets {
+		if op == batchOpUpdate && pending.ID == secret.ID {
+			return true
+		}
+		if op == batchOpCreate && pending.Key == secret.Key && rootedPath(pending.Path) == rootedPath(secret.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// flush sends a pending batch and delivers each secret's result
+func (b *secretBatcher) flush(key batchKey) {
+	b.mu.Lock()
+	batch := b.pending[key]
+	delete(b.pending, key)
+	b.mu.Unlock()
+
+	if batch == nil {
+		return
+	}
+
+	tflog.Debug(batch.ctx, "Sending batched Phase API request", map[string]interface{}{
+		"operation": key.op,
+		"secrets":   len(batch.secrets),
+	})
+
+	results, err := b.send(batch.ctx, key.op, key.appID, key.env, batch.secrets)
+
+	var batchErr *BatchError
+	if err != nil && !errors.As(err, &batchErr) && len(batch.secrets) > 1 {
+		// A failure of the whole request, such as a conflict on one key,
+		// must not fail the other secrets, so send each one on its own
+		for i, secret := range batch.secrets {
+			created, err := b.sendOne(batch.ctx, key.op, key.appID, key.env, secret)
+			batch.results[i] <- batchResult{secret: created, err: err}
+		}
+		return
+	}
+
+	for i, secret := range batch.secrets {
+		batch.results[i] <- matchBatchResult(key.op, secret, results, err)
+	}
+}
+
+// send creates or updates several secrets in one request
+func (b *secretBatcher) send(ctx context.Context, op, appID, env string, secrets []Secret) ([]Secret, error) {
+	if op == batchOpUpdate {
+		return b.client.UpdateSecrets(ctx, appID, env, secrets)
+	}
+	return b.client.CreateSecrets(ctx, appID, env, secrets)
+}
+
+// sendOne creates or updates a single secret without batching
+func (b *secretBatcher) sendOne(ctx context.Context, op, appID, env string, secret Secret) (*Secret, error) {
+	results, err := b.send(ctx, op, appID, env, []Secret{secret})
+	r := matchBatchResult(op, secret, results, err)
+	return r.secret, r.err
+}
+
+// matchBatchResult finds the result for one secret in the response to a batch
+// request
+func matchBatchResult(op string, secret Secret, results []Secret, err error) batchResult {
+	var batchErr *BatchError
+	if err != nil && !errors.As(err, &batchErr) {
+		return batchResult{err: err}
+	}
+
+	for i := range results {
+		if op == batchOpUpdate && results[i].ID == secret.ID {
+			return batchResult{secret: &results[i]}
+		}
+		if op == batchOpCreate && results[i].Key == secret.Key && rootedPath(results[i].Path) == rootedPath(secret.Path) {
+			return batchResult{secret: &results[i]}
+		}
+	}
+
+	if batchErr != nil {
+		for _, failure := range batchErr.Failed {
+			if failure.Key == secret.Key {
+				return batchResult{err: &BatchError{
+					Message:  batchErr.Message,
+					Failed:   []SecretError{failure},
+					APIError: batchErr.APIError,
+				}}
+			}
+		}
+	}
+
+	return batchResult{err: fmt.Errorf("no secret %sd for key %q", op, secret.Key)}
+}
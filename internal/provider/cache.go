@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// cacheTransport wraps an http.RoundTripper and serves repeated GET requests
+// from memory for a short time. Any other request clears the cache, so reads
+// that follow a write within the same run are never stale.
+type cacheTransport struct {
+	base http.RoundTripper
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cachedResponse
+}
+
+// cachedResponse is a successful response held by cacheTransport
+type cachedResponse struct {
+	status     string
+	statusCode int
+	header     http.Header
+	body       []byte
+	expires    time.Time
+}
+
+// newCacheTransport creates a cache that holds responses for ttl
+func newCacheTransport(base http.RoundTripper, ttl time.Duration) *cacheTransport {
+	return &cacheTransport{
+		base:    base,
+		ttl:     ttl,
+		entries: make(map[string]cachedResponse),
+	}
+}
+
+// RoundTrip serves GET requests from the cache when possible and stores
+// successful GET responses. Other requests invalidate the cache.
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		t.clear()
+		defer t.clear()
+		return t.base.RoundTrip(req)
+	}
+
+	// Responses depend on the token, so it is part of the key
+	key := req.Header.Get("Authorization") + " " + req.URL.String()
+
+	t.mu.Lock()
+	entry, ok := t.entries[key]
+	t.mu.Unlock()
+
+	if ok && time.Now().Before(entry.expires) {
+		tflog.Debug(req.Context(), "Serving Phase API request from cache", map[string]interface{}{
+			"method": req.Method,
+			"url":    req.URL.Redacted(),
+		})
+		return &http.Response{
+			Status:        entry.status,
+			StatusCode:    entry.statusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        entry.header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(entry.body)),
+			ContentLength: int64(len(entry.body)),
+			Request:       req,
+		}, nil
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.mu.Lock()
+	t.entries[key] = cachedResponse{
+		status:     resp.Status,
+		statusCode: resp.StatusCode,
+		header:     resp.Header.Clone(),
+		body:       body,
+		expires:    time.Now().Add(t.ttl),
+	}
+	t.mu.Unlock()
+
+	return resp, nil
+}
+
+// clear removes every cached response
+func (t *cacheTransport) clear() {
+	t.mu.Lock()
+	t.entries = make(map[string]cachedResponse)
+	t.mu.Unlock()
+}
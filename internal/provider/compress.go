@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+)
+
+// compressTransport wraps an http.RoundTripper and gzips request bodies that
+// are at least threshold bytes long
+type compressTransport struct {
+	base      http.RoundTripper
+	threshold int
+}
+
+// RoundTrip compresses the request body if it is large enough and then
+// executes the request
+func (t *compressTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body == nil || req.Body == http.NoBody || req.Header.Get("Content-Encoding") != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	compressedReq := req.Clone(req.Context())
+	if len(body) < t.threshold {
+		compressedReq.Body = io.NopCloser(bytes.NewReader(body))
+		return t.base.RoundTrip(compressedReq)
+	}
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(body); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	compressed := buf.Bytes()
+
+	compressedReq.Body = io.NopCloser(bytes.NewReader(compressed))
+	compressedReq.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(compressed)), nil
+	}
+	compressedReq.ContentLength = int64(len(compressed))
+	compressedReq.Header.Set("Content-Encoding", "gzip")
+
+	return t.base.RoundTrip(compressedReq)
+}
@@ -3,6 +3,8 @@ package provider
 import (
 	"net/http"
 	"regexp"
+	"sync"
+	"time"
 )
 
 const (
@@ -12,37 +14,227 @@ const (
 	// DefaultHostURL is the default host for Phase API
 	DefaultHostURL = "https://api.phase.dev"
 
+	// SelfHostedAPIPath is the path self-hosted Phase instances serve the API under
+	SelfHostedAPIPath = "/service/public"
+
+	// DefaultAPIVersion is the default version segment of API paths
+	DefaultAPIVersion = "v1"
+
+	// AllowProtectedDestroyEnv is the environment variable that, when set to
+	// true, allows deleting secrets with prevent_destroy_on_server set
+	AllowProtectedDestroyEnv = "PHASE_ALLOW_PROTECTED_DESTROY"
+
+	// ProtectedTag is added in Phase to secrets with prevent_destroy_on_server
+	// set, so that bulk deletion can skip them. It is never stored in state.
+	ProtectedTag = "terraform-prevent-destroy"
+
 	// UserAgent is the user agent for the provider
 	UserAgent = "terraform-provider-phase/" + Version
+
+	// DefaultRequestTimeout is the default HTTP request timeout in seconds
+	DefaultRequestTimeout = 30
+
+	// DefaultMaxRetries is the default number of times a failed request is retried
+	DefaultMaxRetries = 3
+
+	// DefaultRetryWaitMin is the default minimum wait in seconds between retries
+	DefaultRetryWaitMin = 1
+
+	// DefaultRetryWaitMax is the default maximum wait in seconds between retries
+	DefaultRetryWaitMax = 30
+
+	// DefaultRateLimit is the default maximum number of requests per second
+	DefaultRateLimit = 10
+
+	// DefaultCacheTTL is how long cached read responses are served when
+	// cache_reads is enabled
+	DefaultCacheTTL = 30 * time.Second
+
+	// DefaultCompressThreshold is the request body size in bytes from which
+	// bodies are gzipped when compress_requests is enabled
+	DefaultCompressThreshold = 8 * 1024
+
+	// DefaultMaxIdleConnsPerHost is the default number of idle connections
+	// kept open to the Phase API. It matches Terraform's default parallelism.
+	DefaultMaxIdleConnsPerHost = 10
+
+	// DefaultIdleConnTimeout is the default time in seconds an idle
+	// connection is kept open
+	DefaultIdleConnTimeout = 90
+
+	// DefaultLockboxTTL is the default time in seconds before a lockbox
+	// share expires
+	DefaultLockboxTTL = 24 * 60 * 60
+
+	// DefaultMaxResponseBytes is the default size limit of a response body
+	DefaultMaxResponseBytes = 64 * 1024 * 1024
+
+	// MaxBatchWindow is the longest time in milliseconds a secret write may
+	// wait to be batched with others
+	MaxBatchWindow = 1000
+)
+
+const (
+	// TagMatchAny matches secrets that have at least one of the requested tags
+	TagMatchAny = "any"
+
+	// TagMatchAll matches secrets that have every requested tag
+	TagMatchAll = "all"
+)
+
+const (
+	// TagsModeExact replaces the tags of a secret with the configured ones
+	TagsModeExact = "exact"
+
+	// TagsModeMerge adds the configured tags to a secret, keeping tags added
+	// outside Terraform
+	TagsModeMerge = "merge"
 )
 
 // PhaseClient represents the client for interacting with the Phase API
 type PhaseClient struct {
-	HostURL    string
-	HTTPClient *http.Client
-	Token      string
-	TokenType  string
+	HostURL          string
+	APIVersion       string
+	HTTPClient       *http.Client
+	Token            string
+	TokenType        string
+	ExtraUserAgent   string
+	ExtraHeaders     map[string]string
+	MinimalUserAgent bool
+	ReadOnly         bool
+
+	// PlaceholderPatterns match secret values that are rejected at plan time
+	PlaceholderPatterns []*regexp.Regexp
+
+	// DefaultPath and DefaultTags apply to secrets that do not set their own
+	DefaultPath string
+	DefaultTags []string
+
+	// batcher coalesces single secret writes when batch_window_ms is set
+	batcher *secretBatcher
+
+	// tokenFile is reloaded after an unauthorized response when
+	// token_auto_refresh is set. tokenMu guards Token and TokenType.
+	tokenFile string
+	tokenMu   sync.RWMutex
 }
 
 // Secret represents a secret in the Phase API
 type Secret struct {
-	ID       string          `json:"id,omitempty"`
-	Key      string          `json:"key"`
-	Value    string          `json:"value"`
-	Comment  string          `json:"comment,omitempty"`
-	Path     string          `json:"path,omitempty"`
-	Override *SecretOverride `json:"override,omitempty"`
+	ID            string           `json:"id,omitempty"`
+	Key           string           `json:"key"`
+	Value         string           `json:"value"`
+	Comment       string           `json:"comment,omitempty"`
+	Path          string           `json:"path,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+	Version       int              `json:"version,omitempty"`
+	KeyDigest     string           `json:"keyDigest,omitempty"`
+	Inherited     bool             `json:"inherited,omitempty"`
+	InheritedFrom string           `json:"inheritedFrom,omitempty"`
+	Disabled      bool             `json:"disabled,omitempty"`
+	ExpiresAt     string           `json:"expiresAt,omitempty"`
+	CreatedAt     string           `json:"createdAt,omitempty"`
+	UpdatedAt     string           `json:"updatedAt,omitempty"`
+	Override      *SecretOverride  `json:"override,omitempty"`
+	Overrides     []SecretOverride `json:"overrides,omitempty"`
+}
+
+// SecretFilter selects secrets for bulk operations. Empty fields match any
+// secret, and an empty Path matches secrets at every path. Secrets tagged
+// ProtectedTag are only selected if IncludeProtected is set.
+type SecretFilter struct {
+	Path             string
+	KeyPrefix        string
+	Tag              string
+	IncludeProtected bool
 }
 
-// SecretOverride represents a personal secret override
+// SecretVersion represents a historical version of a secret
+type SecretVersion struct {
+	Version   int    `json:"version"`
+	Value     string `json:"value"`
+	Comment   string `json:"comment,omitempty"`
+	CreatedAt string `json:"createdAt,omitempty"`
+}
+
+// SecretOverride represents a personal secret override. MemberID scopes the
+// override to a specific member; when empty it applies to the token's user.
 type SecretOverride struct {
 	ID       string `json:"id,omitempty"`
+	MemberID string `json:"memberId,omitempty"`
 	Value    string `json:"value"`
 	IsActive bool   `json:"isActive"`
 }
 
+// ServiceToken represents a service token scoped to environments of an app.
+// Token is only returned when the service token is created.
+type ServiceToken struct {
+	ID           string   `json:"id,omitempty"`
+	Name         string   `json:"name"`
+	Environments []string `json:"environments"`
+	Token        string   `json:"token,omitempty"`
+	CreatedAt    string   `json:"createdAt,omitempty"`
+}
+
+// Lockbox is a one-time share of a value. Data is only sent when the share is
+// created, and URL is only returned then.
+type Lockbox struct {
+	ID           string `json:"id,omitempty"`
+	Data         string `json:"data,omitempty"`
+	URL          string `json:"url,omitempty"`
+	TTL          int    `json:"ttl,omitempty"`
+	AllowedViews int    `json:"allowedViews,omitempty"`
+	Views        int    `json:"views,omitempty"`
+	ExpiresAt    string `json:"expiresAt,omitempty"`
+	CreatedAt    string `json:"createdAt,omitempty"`
+}
+
+// Member represents a member with access to a Phase application
+type Member struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+// Health is the status reported by the Phase API health endpoint
+type Health struct {
+	Status  string `json:"status"`
+	Version string `json:"version"`
+}
+
+// App represents an application in the Phase API
+type App struct {
+	ID           string        `json:"id"`
+	Name         string        `json:"name"`
+	Environments []Environment `json:"environments,omitempty"`
+}
+
+// Environment represents an environment within a Phase application
+type Environment struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// EnvironmentInheritance is the parent environment an environment inherits
+// secrets from. InheritsFrom is empty if the environment inherits nothing.
+type EnvironmentInheritance struct {
+	Env          string `json:"env"`
+	InheritsFrom string `json:"inheritsFrom"`
+}
+
 var (
 	// Compiled regex patterns
 	PssUserPattern    = regexp.MustCompile(`^pss_user:v(\d+):([a-fA-F0-9]{64}):([a-fA-F0-9]{64}):([a-fA-F0-9]{64}):([a-fA-F0-9]{64})$`)
 	PssServicePattern = regexp.MustCompile(`^pss_service:v(\d+):([a-fA-F0-9]{64}):([a-fA-F0-9]{64}):([a-fA-F0-9]{64}):([a-fA-F0-9]{64})$`)
+
+	// ServiceTokenTypes maps each known service token version to the token type
+	// sent in the Authorization header
+	ServiceTokenTypes = map[string]string{
+		"1": "Service",
+		"2": "ServiceAccount",
+	}
+
+	// SecretKeyPattern matches valid secret keys: letters, digits and underscores,
+	// not starting with a digit
+	SecretKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
 )
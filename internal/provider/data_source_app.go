@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceApp() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAppRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the app to look up.",
+			},
+			"environments": environmentsSchema(),
+		},
+	}
+}
+
+func dataSourceAppRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	name := d.Get("name").(string)
+
+	app, err := findAppByName(ctx, client, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(app.ID)
+	if err := d.Set("environments", flattenEnvironments(app.Environments)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// findAppByName returns the only app with the given name, failing if there is
+// no such app or the name is ambiguous
+func findAppByName(ctx context.Context, client *PhaseClient, name string) (*App, error) {
+	apps, err := client.ListApps(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []App
+	for _, app := range apps {
+		if app.Name == name {
+			matches = append(matches, app)
+		}
+	}
+
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no app found with name %q", name)
+	}
+	if len(matches) > 1 {
+		return nil, fmt.Errorf("found %d apps with name %q, use the phase_apps data source to choose one by ID", len(matches), name)
+	}
+
+	return &matches[0], nil
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceApps() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAppsRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return apps with exactly this name.",
+			},
+			"apps": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The apps accessible with the configured token.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"environments": environmentsSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+// environmentsSchema is the computed schema for a list of app environments
+func environmentsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAppsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	name := d.Get("name").(string)
+
+	apps, err := client.ListApps(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var appList []interface{}
+	for _, app := range apps {
+		if name != "" && app.Name != name {
+			continue
+		}
+
+		appList = append(appList, map[string]interface{}{
+			"id":           app.ID,
+			"name":         app.Name,
+			"environments": flattenEnvironments(app.Environments),
+		})
+	}
+
+	if err := d.Set("apps", appList); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("apps-%s", name))
+
+	return nil
+}
+
+// flattenEnvironments converts environments into their schema representation
+func flattenEnvironments(environments []Environment) []interface{} {
+	envList := make([]interface{}, 0, len(environments))
+	for _, env := range environments {
+		envList = append(envList, map[string]interface{}{
+			"id":   env.ID,
+			"name": env.Name,
+		})
+	}
+
+	return envList
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceEnvironments() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceEnvironmentsRead,
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the Phase App.",
+			},
+			"environments": environmentsSchema(),
+		},
+	}
+}
+
+func dataSourceEnvironmentsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	appID := d.Get("app_id").(string)
+
+	environments, err := client.ListEnvironments(ctx, appID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("environments", flattenEnvironments(environments)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(appID)
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceHealth() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHealthRead,
+		Schema: map[string]*schema.Schema{
+			"fail_if_unreachable": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Fail with an error if the Phase API cannot be reached or rejects the token. If false, reachable is set to false instead.",
+			},
+			"reachable": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the Phase API was reached and accepted the token.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status reported by the Phase API.",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of the Phase server, if it reports one.",
+			},
+		},
+	}
+}
+
+func dataSourceHealthRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	health, err := client.Ping(ctx)
+	if err != nil {
+		if d.Get("fail_if_unreachable").(bool) {
+			return diag.Errorf("Phase API at %s is not reachable: %s", client.HostURL, err)
+		}
+
+		log.Printf("[WARN] Phase API at %s is not reachable: %s", client.HostURL, err)
+		health = &Health{}
+	}
+
+	d.SetId(client.HostURL)
+	d.Set("reachable", err == nil)
+	d.Set("status", health.Status)
+	d.Set("version", health.Version)
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceMembers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMembersRead,
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the Phase App.",
+			},
+			"role": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return members with this role. Matching is case-insensitive.",
+			},
+			"members": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The members with access to the app.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"role": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceMembersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	appID := d.Get("app_id").(string)
+	role := d.Get("role").(string)
+
+	members, err := client.ListMembers(ctx, appID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	memberList := make([]interface{}, 0, len(members))
+	for _, member := range members {
+		if role != "" && !strings.EqualFold(member.Role, role) {
+			continue
+		}
+
+		memberList = append(memberList, map[string]interface{}{
+			"id":    member.ID,
+			"email": member.Email,
+			"role":  member.Role,
+		})
+	}
+
+	if err := d.Set("members", memberList); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s-%s", appID, role))
+
+	return nil
+}
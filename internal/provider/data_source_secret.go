@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSecret() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSecretRead,
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the Phase App.",
+			},
+			"env": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The environment name.",
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "/",
+				Description: "The path of the secret.",
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The key of the secret to fetch.",
+			},
+			"key_digest": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The digest of the secret key computed by Phase.",
+			},
+			"inherited": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the value is inherited from a parent environment rather than set in this one.",
+			},
+			"inherited_from": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the environment the value is inherited from, if inherited is true.",
+			},
+			"decode_base64": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Base64-decode the value. Reading fails if the value is not valid base64 or does not decode to UTF-8 text.",
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The value of the secret, or the override value if a Personal Secret Override is active.",
+			},
+			"comment": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The comment on the secret.",
+			},
+			"tags": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The tags on the secret.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The version of the secret.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the secret was created.",
+			},
+			"updated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the secret was last updated.",
+			},
+			"override": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The Personal Secret Override for the secret, if any.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"value": {
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+						"is_active": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSecretRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	appID := d.Get("app_id").(string)
+	env := d.Get("env").(string)
+	path := normalizePath(d.Get("path").(string))
+	key := d.Get("key").(string)
+
+	secrets, err := client.ReadSecret(ctx, appID, env, key)
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	var matches []Secret
+	for _, secret := range secrets {
+		if secret.Key == key && secret.Path == path {
+			matches = append(matches, secret)
+		}
+	}
+
+	if len(matches) == 0 {
+		return diag.Errorf("no secret found with key %q at path %q", key, path)
+	}
+	if len(matches) > 1 {
+		return diag.Errorf("found %d secrets with key %q at path %q, expected exactly one", len(matches), key, path)
+	}
+
+	secret := matches[0]
+
+	d.SetId(secret.ID)
+	d.Set("key_digest", secret.KeyDigest)
+	d.Set("inherited", secret.Inherited)
+	d.Set("inherited_from", secret.InheritedFrom)
+	d.Set("comment", secret.Comment)
+	d.Set("tags", secret.Tags)
+	d.Set("version", secret.Version)
+	d.Set("created_at", secret.CreatedAt)
+	d.Set("updated_at", secret.UpdatedAt)
+
+	if secret.Override != nil {
+		d.Set("override", []interface{}{
+			map[string]interface{}{
+				"value":     secret.Override.Value,
+				"is_active": secret.Override.IsActive,
+			},
+		})
+	} else {
+		d.Set("override", []interface{}{})
+	}
+
+	value := secretValue(secret)
+	if d.Get("decode_base64").(bool) {
+		value, err = decodeBase64Value(key, value)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	d.Set("value", value)
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSecretPaths() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSecretPathsRead,
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the Phase App.",
+			},
+			"env": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The environment name.",
+			},
+			"paths": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The distinct paths that contain secrets, sorted.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"secret_counts": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "A map of each path to the number of secrets at that path.",
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSecretPathsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	appID := d.Get("app_id").(string)
+	env := d.Get("env").(string)
+
+	secrets, err := client.ReadSecret(ctx, appID, env, "")
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	counts := make(map[string]int)
+	for _, secret := range secrets {
+		counts[rootedPath(secret.Path)]++
+	}
+
+	paths := make([]string, 0, len(counts))
+	secretCounts := make(map[string]interface{}, len(counts))
+	for path, count := range counts {
+		paths = append(paths, path)
+		secretCounts[path] = count
+	}
+	sort.Strings(paths)
+
+	if err := d.Set("paths", paths); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("secret_counts", secretCounts); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", appID, env))
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceSecretVersion() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSecretVersionRead,
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the Phase App.",
+			},
+			"env": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The environment name.",
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "/",
+				Description: "The path of the secret.",
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The key of the secret to fetch.",
+			},
+			"version": {
+				Type:             schema.TypeInt,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				Description:      "The version of the secret to fetch.",
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The value of the secret at this version.",
+			},
+			"comment": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The comment on the secret at this version.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time this version was created.",
+			},
+		},
+	}
+}
+
+func dataSourceSecretVersionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	appID := d.Get("app_id").(string)
+	env := d.Get("env").(string)
+	path := normalizePath(d.Get("path").(string))
+	key := d.Get("key").(string)
+	version := d.Get("version").(int)
+
+	secrets, err := client.ReadSecret(ctx, appID, env, key)
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	var secret *Secret
+	for i := range secrets {
+		if secrets[i].Key == key && secrets[i].Path == path {
+			secret = &secrets[i]
+			break
+		}
+	}
+	if secret == nil {
+		return diag.Errorf("no secret found with key %q at path %q", key, path)
+	}
+
+	secretVersion, err := client.ReadSecretVersion(ctx, appID, env, secret.ID, version)
+	if isNotFound(err) {
+		return diag.Errorf("version %d of secret %q at path %q does not exist", version, key, path)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%d", secret.ID, version))
+	d.Set("value", secretVersion.Value)
+	d.Set("comment", secretVersion.Comment)
+	d.Set("created_at", secretVersion.CreatedAt)
+
+	return nil
+}
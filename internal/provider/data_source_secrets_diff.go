@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSecretsDiff() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSecretsDiffRead,
+		Schema: map[string]*schema.Schema{
+			"a": secretsDiffSideSchema("The first set of secrets to compare."),
+			"b": secretsDiffSideSchema("The second set of secrets to compare."),
+			"only_in_a": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The keys that only exist in a, sorted.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"only_in_b": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The keys that only exist in b, sorted.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"values_differ": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The keys that exist in both but have different values, sorted.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// secretsDiffSideSchema returns the schema of one side of a phase_secrets_diff
+func secretsDiffSideSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    true,
+		MaxItems:    1,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"app_id": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The ID of the Phase App.",
+				},
+				"env": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The environment name.",
+				},
+				"path": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "/",
+					Description: "The path of the secrets to compare.",
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSecretsDiffRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	a := d.Get("a").([]interface{})[0].(map[string]interface{})
+	b := d.Get("b").([]interface{})[0].(map[string]interface{})
+
+	valuesA, err := secretsDiffValues(ctx, client, a)
+	if err != nil {
+		return diag.Errorf("error reading secrets for a: %s", err)
+	}
+
+	valuesB, err := secretsDiffValues(ctx, client, b)
+	if err != nil {
+		return diag.Errorf("error reading secrets for b: %s", err)
+	}
+
+	onlyInA := make([]string, 0)
+	onlyInB := make([]string, 0)
+	valuesDiffer := make([]string, 0)
+
+	for key, valueA := range valuesA {
+		valueB, ok := valuesB[key]
+		if !ok {
+			onlyInA = append(onlyInA, key)
+		} else if valueA != valueB {
+			valuesDiffer = append(valuesDiffer, key)
+		}
+	}
+	for key := range valuesB {
+		if _, ok := valuesA[key]; !ok {
+			onlyInB = append(onlyInB, key)
+		}
+	}
+
+	sort.Strings(onlyInA)
+	sort.Strings(onlyInB)
+	sort.Strings(valuesDiffer)
+
+	if err := d.Set("only_in_a", onlyInA); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("only_in_b", onlyInB); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("values_differ", valuesDiffer); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s%s:%s/%s%s",
+		a["app_id"].(string), a["env"].(string), normalizePath(a["path"].(string)),
+		b["app_id"].(string), b["env"].(string), normalizePath(b["path"].(string)),
+	))
+
+	return nil
+}
+
+// secretsDiffValues returns the stored value of each secret at the path of one
+// side of a phase_secrets_diff, keyed by secret key
+func secretsDiffValues(ctx context.Context, client *PhaseClient, side map[string]interface{}) (map[string]string, error) {
+	path := normalizePath(side["path"].(string))
+
+	secrets, err := client.ListSecrets(ctx, side["app_id"].(string), side["env"].(string), path)
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	for _, secret := range secrets {
+		if secret.Path == path {
+			values[secret.Key] = secret.Value
+		}
+	}
+
+	return values, nil
+}
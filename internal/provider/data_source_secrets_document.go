@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// DocumentFormatDotenv renders secrets as KEY="value" lines
+	DocumentFormatDotenv = "dotenv"
+
+	// DocumentFormatJSON renders secrets as a JSON object
+	DocumentFormatJSON = "json"
+
+	// DocumentFormatYAML renders secrets as a YAML mapping
+	DocumentFormatYAML = "yaml"
+)
+
+func dataSourceSecretsDocument() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSecretsDocumentRead,
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the Phase App.",
+			},
+			"env": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The environment name.",
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "/",
+				Description: "The path to fetch secrets from. An empty path fetches secrets from all paths.",
+			},
+			"format": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{DocumentFormatDotenv, DocumentFormatJSON, DocumentFormatYAML}, false)),
+				Description:      "The format to render the secrets in: dotenv, json or yaml.",
+			},
+			"content": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The rendered secrets, sorted by key.",
+			},
+		},
+	}
+}
+
+func dataSourceSecretsDocumentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	appID := d.Get("app_id").(string)
+	env := d.Get("env").(string)
+	path := normalizePath(d.Get("path").(string))
+	format := d.Get("format").(string)
+
+	secrets, err := client.ReadSecret(ctx, appID, env, "")
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	secretMap := make(map[string]string)
+	for _, secret := range secrets {
+		if path == "" || secret.Path == path {
+			secretMap[secret.Key] = secretValue(secret)
+		}
+	}
+
+	content, err := renderSecretsDocument(secretMap, format)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("content", content); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s-%s-%s-%s", appID, env, path, format))
+
+	return nil
+}
+
+// renderSecretsDocument renders secrets in the given format with keys sorted
+// so the output is deterministic
+func renderSecretsDocument(secrets map[string]string, format string) (string, error) {
+	keys := make([]string, 0, len(secrets))
+	for key := range secrets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	switch format {
+	case DocumentFormatDotenv:
+		for _, key := range keys {
+			fmt.Fprintf(&b, "%s=%s\n", key, quoteDotenvValue(secrets[key]))
+		}
+	case DocumentFormatJSON:
+		// encoding/json sorts map keys
+		data, err := json.MarshalIndent(secrets, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		b.Write(data)
+		b.WriteString("\n")
+	case DocumentFormatYAML:
+		for _, key := range keys {
+			// JSON strings are valid YAML double-quoted scalars
+			quotedKey, err := json.Marshal(key)
+			if err != nil {
+				return "", err
+			}
+			quotedValue, err := json.Marshal(secrets[key])
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(&b, "%s: %s\n", quotedKey, quotedValue)
+		}
+	default:
+		return "", fmt.Errorf("unsupported document format %q", format)
+	}
+
+	return b.String(), nil
+}
+
+// quoteDotenvValue double-quotes a value, escaping characters that would
+// otherwise break dotenv parsing
+func quoteDotenvValue(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\n", `\n`,
+		"\r", `\r`,
+	)
+	return `"` + replacer.Replace(value) + `"`
+}
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSecretsTemplate() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSecretsTemplateRead,
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the Phase App.",
+			},
+			"env": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The environment name.",
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "/",
+				Description: "The path to fetch secrets from. An empty path fetches secrets from all paths.",
+			},
+			"template": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateSecretsTemplate,
+				Description:      "A Go text/template rendered with the fetched secrets. See the documentation for the available fields and functions.",
+			},
+			"rendered": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The rendered template.",
+			},
+		},
+	}
+}
+
+// secretsTemplateData is the context a secrets template is rendered with
+type secretsTemplateData struct {
+	AppID    string
+	Env      string
+	Path     string
+	Keys     []string
+	Secrets  map[string]string
+	Metadata map[string]secretsTemplateMetadata
+}
+
+// secretsTemplateMetadata describes a secret to a secrets template
+type secretsTemplateMetadata struct {
+	Path      string
+	Comment   string
+	Tags      []string
+	Version   int
+	CreatedAt string
+	UpdatedAt string
+}
+
+// secretsTemplateFuncs are the functions available to secrets templates, for
+// escaping values in common formats
+var secretsTemplateFuncs = template.FuncMap{
+	"json": func(value string) (string, error) {
+		data, err := json.Marshal(value)
+		return string(data), err
+	},
+	"xml": func(value string) (string, error) {
+		var b strings.Builder
+		err := xml.EscapeText(&b, []byte(value))
+		return b.String(), err
+	},
+	"dotenv": quoteDotenvValue,
+	"upper":  strings.ToUpper,
+	"lower":  strings.ToLower,
+	"join":   strings.Join,
+}
+
+// parseSecretsTemplate parses a secrets template. Referencing a missing map
+// key, such as a secret that does not exist, fails rendering.
+func parseSecretsTemplate(text string) (*template.Template, error) {
+	return template.New("template").Funcs(secretsTemplateFuncs).Option("missingkey=error").Parse(text)
+}
+
+// validateSecretsTemplate checks at plan time that a template parses
+func validateSecretsTemplate(v interface{}, path cty.Path) diag.Diagnostics {
+	if _, err := parseSecretsTemplate(v.(string)); err != nil {
+		return diag.Errorf("invalid template: %s", err)
+	}
+	return nil
+}
+
+func dataSourceSecretsTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	appID := d.Get("app_id").(string)
+	env := d.Get("env").(string)
+	path := normalizePath(d.Get("path").(string))
+	text := d.Get("template").(string)
+
+	tmpl, err := parseSecretsTemplate(text)
+	if err != nil {
+		return diag.Errorf("invalid template: %s", err)
+	}
+
+	secrets, err := client.ReadSecret(ctx, appID, env, "")
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	data := secretsTemplateData{
+		AppID:    appID,
+		Env:      env,
+		Path:     path,
+		Keys:     []string{},
+		Secrets:  make(map[string]string),
+		Metadata: make(map[string]secretsTemplateMetadata),
+	}
+	for _, secret := range secrets {
+		if path != "" && secret.Path != path {
+			continue
+		}
+		data.Keys = append(data.Keys, secret.Key)
+		data.Secrets[secret.Key] = secretValue(secret)
+		data.Metadata[secret.Key] = secretsTemplateMetadata{
+			Path:      secret.Path,
+			Comment:   secret.Comment,
+			Tags:      secret.Tags,
+			Version:   secret.Version,
+			CreatedAt: secret.CreatedAt,
+			UpdatedAt: secret.UpdatedAt,
+		}
+	}
+	sort.Strings(data.Keys)
+
+	// Template errors name keys and fields, never secret values
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return diag.Errorf("failed to render template: %s", err)
+	}
+
+	if err := d.Set("rendered", rendered.String()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	sum := sha256.Sum256([]byte(text))
+	d.SetId(fmt.Sprintf("%s-%s-%s-%s", appID, env, path, hex.EncodeToString(sum[:])))
+
+	return nil
+}
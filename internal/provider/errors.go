@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// ErrNotFound is returned when a request succeeds but matches no secrets
+var ErrNotFound = errors.New("no secrets found")
+
+// ErrReadOnly is returned for requests that would modify Phase while the
+// provider is configured with read_only
+var ErrReadOnly = errors.New("the provider is configured with read_only = true and will not modify Phase")
+
+// ErrResponseTooLarge is returned when a response body is larger than
+// max_response_bytes
+var ErrResponseTooLarge = errors.New("the Phase API response is larger than max_response_bytes")
+
+// APIError is returned when the Phase API responds with an unexpected status code
+type APIError struct {
+	// Message describes the operation that failed
+	Message    string
+	StatusCode int
+	Status     string
+	Body       string
+	// RequestID identifies the request in Phase's logs, if the response
+	// included one. Include it when contacting Phase support.
+	RequestID string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s: %s", e.Message, e.Status)
+	if e.Body != "" {
+		msg = fmt.Sprintf("%s - %s", msg, e.Body)
+	}
+	if e.RequestID != "" {
+		msg = fmt.Sprintf("%s (request ID: %s)", msg, e.RequestID)
+	}
+	return msg
+}
+
+// requestIDHeaders are the response headers that may carry a request ID, in
+// order of preference
+var requestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id", "Cf-Ray"}
+
+// requestID returns the request ID from a response, if it has one
+func requestID(resp *http.Response) string {
+	for _, header := range requestIDHeaders {
+		if id := resp.Header.Get(header); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// newAPIError builds an APIError from an unsuccessful response, redacting
+// sensitive fields and the given sensitive values from the body. A non-JSON
+// body, such as an HTML error page from a proxy, is shortened to its text.
+func newAPIError(message string, resp *http.Response, body []byte, sensitiveValues ...string) *APIError {
+	if len(bytes.TrimSpace(body)) > 0 && isNonJSONResponse(resp, body) {
+		body = []byte(summarizeBody(body))
+	}
+
+	return &APIError{
+		Message:    message,
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       redactBody(body, sensitiveValues...),
+		RequestID:  requestID(resp),
+	}
+}
+
+// maxSummaryLength is the number of characters of a non-JSON response body
+// included in an error
+const maxSummaryLength = 200
+
+var (
+	// htmlSkippedPattern matches HTML elements whose content is not text
+	htmlSkippedPattern = regexp.MustCompile(`(?is)<(script|style|head)\b.*?</(script|style|head)>`)
+
+	// htmlTagPattern matches a single HTML tag or comment
+	htmlTagPattern = regexp.MustCompile(`(?s)<!--.*?-->|<[^>]*>`)
+)
+
+// isNonJSONResponse reports whether a response body is not JSON, judging by a
+// leading "<" or a Content-Type that is not JSON. An empty body is not JSON.
+func isNonJSONResponse(resp *http.Response, body []byte) bool {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || trimmed[0] == '<' {
+		return true
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json") && !json.Valid(trimmed)
+}
+
+// summarizeBody reduces a non-JSON body to a single line of its text, without
+// HTML markup, truncated to maxSummaryLength characters
+func summarizeBody(body []byte) string {
+	text := htmlSkippedPattern.ReplaceAllString(string(body), " ")
+	text = htmlTagPattern.ReplaceAllString(text, " ")
+	text = strings.Join(strings.Fields(html.UnescapeString(text)), " ")
+
+	if text == "" {
+		return "(empty response body)"
+	}
+	if runes := []rune(text); len(runes) > maxSummaryLength {
+		return string(runes[:maxSummaryLength]) + "..."
+	}
+	return text
+}
+
+// decodeJSON decodes a successful response body into v. A body that is not
+// JSON is reported as an APIError with the status and a summary of the body,
+// rather than as a JSON syntax error. The given sensitive values are redacted
+// from the summary.
+func decodeJSON(message string, resp *http.Response, body []byte, v interface{}, sensitiveValues ...string) error {
+	if isNonJSONResponse(resp, body) {
+		apiErr := newAPIError(message, resp, body, sensitiveValues...)
+		apiErr.Message = fmt.Sprintf("%s: expected a JSON response but got %s", message, describeContentType(resp))
+		return apiErr
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("%s: invalid JSON response: %w", message, err)
+	}
+	return nil
+}
+
+// describeContentType names the Content-Type of a response for errors
+func describeContentType(resp *http.Response) string {
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		return contentType
+	}
+	return "a response without a Content-Type"
+}
+
+// SecretError describes why a single secret in a batch request failed
+type SecretError struct {
+	Key   string `json:"key"`
+	Error string `json:"error"`
+}
+
+// BatchError is returned when some secrets in a batch request fail while the
+// rest succeed. Succeeded holds the secrets that were written.
+type BatchError struct {
+	// Message describes the operation that failed
+	Message   string
+	Succeeded []Secret
+	Failed    []SecretError
+	APIError  *APIError
+}
+
+func (e *BatchError) Error() string {
+	reasons := make([]string, 0, len(e.Failed))
+	for _, failure := range e.Failed {
+		reasons = append(reasons, fmt.Sprintf("%s (%s)", failure.Key, failure.Error))
+	}
+	msg := fmt.Sprintf("%s: %d of %d secrets failed: %s", e.Message, len(e.Failed), len(e.Failed)+len(e.Succeeded), strings.Join(reasons, ", "))
+	if e.APIError != nil && e.APIError.RequestID != "" {
+		msg = fmt.Sprintf("%s (request ID: %s)", msg, e.APIError.RequestID)
+	}
+	return msg
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.APIError
+}
+
+// newBatchError parses per-secret results from an unsuccessful batch
+// response. It returns nil if the body does not report individual failures.
+func newBatchError(message string, resp *http.Response, body []byte, sensitiveValues ...string) *BatchError {
+	var results struct {
+		Secrets []Secret      `json:"secrets"`
+		Errors  []SecretError `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &results); err != nil || len(results.Errors) == 0 {
+		return nil
+	}
+
+	for i := range results.Errors {
+		results.Errors[i].Error = redactBody([]byte(results.Errors[i].Error), sensitiveValues...)
+	}
+
+	return &BatchError{
+		Message:   message,
+		Succeeded: results.Secrets,
+		Failed:    results.Errors,
+		APIError:  newAPIError(message, resp, body, sensitiveValues...),
+	}
+}
+
+// isNotFound reports whether err means the requested object does not exist
+func isNotFound(err error) bool {
+	if errors.Is(err, ErrNotFound) {
+		return true
+	}
+
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+)
+
+const (
+	// GenerateTypeHex generates a random hex string
+	GenerateTypeHex = "hex"
+
+	// GenerateTypeBase64 generates a random base64 string
+	GenerateTypeBase64 = "base64"
+
+	// GenerateTypeAlphanumeric generates a random string of letters and digits
+	GenerateTypeAlphanumeric = "alphanumeric"
+
+	alphanumericCharset = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+)
+
+// generateSecretValue returns a cryptographically random value of the given
+// type that is exactly length characters long
+func generateSecretValue(genType string, length int) (string, error) {
+	switch genType {
+	case GenerateTypeHex:
+		buf := make([]byte, (length+1)/2)
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(buf)[:length], nil
+	case GenerateTypeBase64:
+		buf := make([]byte, base64.RawStdEncoding.DecodedLen(length)+1)
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+		return base64.RawStdEncoding.EncodeToString(buf)[:length], nil
+	case GenerateTypeAlphanumeric:
+		value := make([]byte, length)
+		max := big.NewInt(int64(len(alphanumericCharset)))
+		for i := range value {
+			n, err := rand.Int(rand.Reader, max)
+			if err != nil {
+				return "", err
+			}
+			value[i] = alphanumericCharset[n.Int64()]
+		}
+		return string(value), nil
+	default:
+		return "", fmt.Errorf("unsupported generate type %q", genType)
+	}
+}
@@ -0,0 +1,450 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// testResource drives a resource through the provider's gRPC server, so that
+// plans and applies see the same raw config, defaults and CustomizeDiff
+// functions as they do under Terraform
+type testResource struct {
+	t        *testing.T
+	server   tfprotov5.ProviderServer
+	typeName string
+	computed map[string]bool
+	ty       cty.Type
+}
+
+func newTestResource(t *testing.T, client *PhaseClient, typeName string) *testResource {
+	t.Helper()
+
+	p := Provider()
+	p.SetMeta(client)
+
+	res, ok := p.ResourcesMap[typeName]
+	if !ok {
+		t.Fatalf("resource %s is not registered", typeName)
+	}
+
+	coreSchema := res.CoreConfigSchema()
+	computed := make(map[string]bool)
+	for name, attr := range coreSchema.Attributes {
+		computed[name] = attr.Computed
+	}
+
+	return &testResource{
+		t:        t,
+		server:   schema.NewGRPCProviderServer(p),
+		typeName: typeName,
+		computed: computed,
+		ty:       coreSchema.ImpliedType(),
+	}
+}
+
+// config builds a configuration from the given attributes, leaving every
+// other attribute null
+func (r *testResource) config(attrs map[string]cty.Value) cty.Value {
+	r.t.Helper()
+
+	values := make(map[string]cty.Value)
+	for name, attrType := range r.ty.AttributeTypes() {
+		if v, ok := attrs[name]; ok {
+			values[name] = v
+		} else {
+			values[name] = cty.NullVal(attrType)
+		}
+	}
+	for name := range attrs {
+		if !r.ty.HasAttribute(name) {
+			r.t.Fatalf("%s has no attribute %q", r.typeName, name)
+		}
+	}
+
+	return cty.ObjectVal(values)
+}
+
+// proposedNew merges config with prior the way Terraform does before asking
+// the provider to plan: computed attributes left unset keep their prior value
+func (r *testResource) proposedNew(prior, config cty.Value) cty.Value {
+	if prior.IsNull() {
+		return config
+	}
+
+	values := make(map[string]cty.Value)
+	for name := range r.ty.AttributeTypes() {
+		v := config.GetAttr(name)
+		if v.IsNull() && r.computed[name] {
+			v = prior.GetAttr(name)
+		}
+		values[name] = v
+	}
+	return cty.ObjectVal(values)
+}
+
+// testPlan is the result of planning a change to a resource
+type testPlan struct {
+	prior           cty.Value
+	config          cty.Value
+	planned         cty.Value
+	private         []byte
+	requiresReplace []*tftypes.AttributePath
+	diagnostics     []*tfprotov5.Diagnostic
+}
+
+// plan plans the change from prior to config. A null prior plans a create.
+func (r *testResource) plan(prior, config cty.Value) *testPlan {
+	r.t.Helper()
+
+	resp, err := r.server.PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{
+		TypeName:         r.typeName,
+		PriorState:       r.encode(prior),
+		ProposedNewState: r.encode(r.proposedNew(prior, config)),
+		Config:           r.encode(config),
+	})
+	if err != nil {
+		r.t.Fatalf("planning %s: %s", r.typeName, err)
+	}
+
+	plan := &testPlan{
+		prior:           prior,
+		config:          config,
+		private:         resp.PlannedPrivate,
+		requiresReplace: resp.RequiresReplace,
+		diagnostics:     resp.Diagnostics,
+	}
+	if resp.PlannedState != nil {
+		plan.planned = r.decode(resp.PlannedState)
+	}
+	return plan
+}
+
+// apply applies a plan and returns the new state
+func (r *testResource) apply(plan *testPlan) (cty.Value, []*tfprotov5.Diagnostic) {
+	r.t.Helper()
+
+	resp, err := r.server.ApplyResourceChange(context.Background(), &tfprotov5.ApplyResourceChangeRequest{
+		TypeName:       r.typeName,
+		PriorState:     r.encode(plan.prior),
+		PlannedState:   r.encode(plan.planned),
+		Config:         r.encode(plan.config),
+		PlannedPrivate: plan.private,
+	})
+	if err != nil {
+		r.t.Fatalf("applying %s: %s", r.typeName, err)
+	}
+
+	return r.decode(resp.NewState), resp.Diagnostics
+}
+
+// read refreshes state, failing the test on errors
+func (r *testResource) read(state cty.Value) cty.Value {
+	r.t.Helper()
+
+	resp, err := r.server.ReadResource(context.Background(), &tfprotov5.ReadResourceRequest{
+		TypeName:     r.typeName,
+		CurrentState: r.encode(state),
+	})
+	if err != nil {
+		r.t.Fatalf("reading %s: %s", r.typeName, err)
+	}
+	requireNoErrors(r.t, resp.Diagnostics)
+
+	return r.decode(resp.NewState)
+}
+
+// create plans and applies config from scratch, failing the test on errors
+func (r *testResource) create(config cty.Value) cty.Value {
+	r.t.Helper()
+	return r.update(cty.NullVal(r.ty), config)
+}
+
+// update plans and applies config on top of prior, failing the test on errors
+func (r *testResource) update(prior, config cty.Value) cty.Value {
+	r.t.Helper()
+
+	plan := r.plan(prior, config)
+	requireNoErrors(r.t, plan.diagnostics)
+
+	state, diags := r.apply(plan)
+	requireNoErrors(r.t, diags)
+	return state
+}
+
+// destroy applies the deletion of prior
+func (r *testResource) destroy(prior cty.Value) []*tfprotov5.Diagnostic {
+	r.t.Helper()
+
+	null := cty.NullVal(r.ty)
+	_, diags := r.apply(&testPlan{prior: prior, config: null, planned: null})
+	return diags
+}
+
+func (r *testResource) encode(v cty.Value) *tfprotov5.DynamicValue {
+	r.t.Helper()
+
+	data, err := msgpack.Marshal(v, r.ty)
+	if err != nil {
+		r.t.Fatalf("encoding %s: %s", r.typeName, err)
+	}
+	return &tfprotov5.DynamicValue{MsgPack: data}
+}
+
+func (r *testResource) decode(dv *tfprotov5.DynamicValue) cty.Value {
+	r.t.Helper()
+
+	v, err := msgpack.Unmarshal(dv.MsgPack, r.ty)
+	if err != nil {
+		r.t.Fatalf("decoding %s: %s", r.typeName, err)
+	}
+	return v
+}
+
+// changed reports whether the plan changes the named attribute
+func (p *testPlan) changed(name string) bool {
+	if p.prior.IsNull() {
+		return true
+	}
+	return !p.prior.GetAttr(name).RawEquals(p.planned.GetAttr(name))
+}
+
+func requireNoErrors(t *testing.T, diags []*tfprotov5.Diagnostic) {
+	t.Helper()
+
+	for _, d := range diags {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Fatalf("unexpected error: %s: %s", d.Summary, d.Detail)
+		}
+	}
+}
+
+// fakePhase is an in-memory stand-in for the secrets API of a single app.
+// Secrets are kept per environment, and the app ID is ignored.
+type fakePhase struct {
+	t       *testing.T
+	server  *httptest.Server
+	mu      sync.Mutex
+	secrets map[string][]Secret
+	nextID  int
+
+	// reject maps keys the fake refuses to write to the reason it reports in
+	// a partial batch failure
+	reject map[string]string
+
+	// requests records the method and query of every secrets request
+	requests []string
+}
+
+func newFakePhase(t *testing.T) *fakePhase {
+	t.Helper()
+
+	f := &fakePhase{t: t, secrets: make(map[string][]Secret), reject: make(map[string]string)}
+	f.server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// client returns a client for the fake API authenticated with a service token
+func (f *fakePhase) client() *PhaseClient {
+	return &PhaseClient{
+		HostURL:          f.server.URL,
+		APIVersion:       DefaultAPIVersion,
+		HTTPClient:       f.server.Client(),
+		Token:            "token",
+		TokenType:        "ServiceAccount",
+		MinimalUserAgent: true,
+		DefaultPath:      "/",
+	}
+}
+
+// add stores a secret in the Development environment as if it had been
+// created outside Terraform
+func (f *fakePhase) add(secret Secret) Secret {
+	return f.addTo("Development", secret)
+}
+
+// addTo stores a secret in env as if it had been created outside Terraform
+func (f *fakePhase) addTo(env string, secret Secret) Secret {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if secret.ID == "" {
+		secret.ID = f.newID()
+	}
+	secret.Path = rootedPath(secret.Path)
+	if secret.Version == 0 {
+		secret.Version = 1
+	}
+	f.secrets[env] = append(f.secrets[env], secret)
+	return secret
+}
+
+// remove deletes a secret from the Development environment as if it had
+// been deleted outside Terraform
+func (f *fakePhase) remove(id string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.secrets["Development"] = slices.DeleteFunc(f.secrets["Development"], func(s Secret) bool { return s.ID == id })
+}
+
+// list returns the secrets stored in the Development environment
+func (f *fakePhase) list() []Secret {
+	return f.listIn("Development")
+}
+
+// listIn returns the secrets stored in env
+func (f *fakePhase) listIn(env string) []Secret {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return slices.Clone(f.secrets[env])
+}
+
+// countRequests returns how many recorded requests match method and query
+func (f *fakePhase) countRequests(method, query string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	n := 0
+	for _, r := range f.requests {
+		if r == method+" "+query {
+			n++
+		}
+	}
+	return n
+}
+
+func (f *fakePhase) newID() string {
+	f.nextID++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", f.nextID)
+}
+
+func (f *fakePhase) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.URL.Path != "/v1/secrets/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	query := r.URL.Query()
+	query.Del("page_size")
+	f.requests = append(f.requests, r.Method+" "+query.Encode())
+	env := query.Get("env")
+
+	var body struct {
+		Secrets json.RawMessage `json:"secrets"`
+	}
+	if r.Method != http.MethodGet {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		matched := []Secret{}
+		for _, s := range f.secrets[env] {
+			if key := query.Get("key"); key != "" && s.Key != key {
+				continue
+			}
+			if path := query.Get("path"); path != "" && s.Path != path {
+				continue
+			}
+			matched = append(matched, s)
+		}
+		writeJSON(w, matched)
+
+	case http.MethodPost:
+		var secrets []Secret
+		json.Unmarshal(body.Secrets, &secrets)
+		secrets, failed := f.rejected(secrets)
+		for i := range secrets {
+			secrets[i].Path = rootedPath(secrets[i].Path)
+			for _, s := range f.secrets[env] {
+				if (s.Key == secrets[i].Key && s.Path == secrets[i].Path) || (secrets[i].ID != "" && s.ID == secrets[i].ID) {
+					http.Error(w, `{"error":"conflict"}`, http.StatusConflict)
+					return
+				}
+			}
+			if secrets[i].ID == "" {
+				secrets[i].ID = f.newID()
+			}
+			secrets[i].Version = 1
+			f.secrets[env] = append(f.secrets[env], secrets[i])
+		}
+		writeBatch(w, secrets, failed)
+
+	case http.MethodPut:
+		var secrets []Secret
+		json.Unmarshal(body.Secrets, &secrets)
+		secrets, failed := f.rejected(secrets)
+		for i := range secrets {
+			index := slices.IndexFunc(f.secrets[env], func(s Secret) bool { return s.ID == secrets[i].ID })
+			if index < 0 {
+				http.Error(w, `{"error":"not found"}`, http.StatusNotFound)
+				return
+			}
+			secrets[i].Path = rootedPath(secrets[i].Path)
+			secrets[i].Version = f.secrets[env][index].Version + 1
+			f.secrets[env][index] = secrets[i]
+		}
+		writeBatch(w, secrets, failed)
+
+	case http.MethodDelete:
+		var ids []string
+		json.Unmarshal(body.Secrets, &ids)
+		f.secrets[env] = slices.DeleteFunc(f.secrets[env], func(s Secret) bool { return slices.Contains(ids, s.ID) })
+		writeJSON(w, map[string]string{})
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// rejected splits secrets into those the fake accepts and failures for the
+// keys in f.reject
+func (f *fakePhase) rejected(secrets []Secret) ([]Secret, []SecretError) {
+	var failed []SecretError
+	accepted := slices.DeleteFunc(secrets, func(s Secret) bool {
+		reason, ok := f.reject[s.Key]
+		if ok {
+			failed = append(failed, SecretError{Key: s.Key, Error: reason})
+		}
+		return ok
+	})
+	return accepted, failed
+}
+
+// writeBatch writes the written secrets, or a partial batch failure when any
+// secrets failed
+func writeBatch(w http.ResponseWriter, secrets []Secret, failed []SecretError) {
+	if len(failed) == 0 {
+		writeJSON(w, secrets)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(map[string]interface{}{"secrets": secrets, "errors": failed})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
@@ -2,58 +2,151 @@ package provider
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"os/user"
 	"runtime"
+	"slices"
 	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// authHeader returns the Authorization header value for the client's token.
+// The Phase API expects the Bearer scheme followed by the token type, e.g.
+// "Bearer ServiceAccount <token>" or "Bearer User <token>".
+func (c *PhaseClient) authHeader() string {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	return c.authHeaderLocked()
+}
+
+// authHeaderLocked returns the Authorization header value while tokenMu is held
+func (c *PhaseClient) authHeaderLocked() string {
+	return fmt.Sprintf("Bearer %s %s", c.TokenType, c.Token)
+}
+
 // setHeaders sets the common headers for all requests
-func (c *PhaseClient) setHeaders(req *http.Request, tokenType string) {
+func (c *PhaseClient) setHeaders(req *http.Request) {
 	osType := runtime.GOOS
 	architecture := runtime.GOARCH
-	
+
 	details := []string{fmt.Sprintf("%s %s", osType, architecture)}
 
-	currentUser, err := user.Current()
-	if err == nil {
-		hostname, err := os.Hostname()
+	if !c.MinimalUserAgent {
+		currentUser, err := user.Current()
 		if err == nil {
-			userHostString := fmt.Sprintf("%s@%s", currentUser.Username, hostname)
-			details = append(details, userHostString)
+			hostname, err := os.Hostname()
+			if err == nil {
+				userHostString := fmt.Sprintf("%s@%s", currentUser.Username, hostname)
+				details = append(details, userHostString)
+			}
 		}
 	}
 
 	userAgent := fmt.Sprintf("%s (%s)", UserAgent, strings.Join(details, "; "))
+	if c.ExtraUserAgent != "" {
+		userAgent = fmt.Sprintf("%s %s", userAgent, c.ExtraUserAgent)
+	}
+
+	// Extra headers are set first so they cannot replace the headers the
+	// Phase API relies on
+	for name, value := range c.ExtraHeaders {
+		req.Header.Set(name, value)
+	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("%s %s", tokenType, c.Token))
+	req.Header.Set("Authorization", c.authHeader())
 	req.Header.Set("User-Agent", userAgent)
 }
 
-// CreateSecret creates a new secret
-func (c *PhaseClient) CreateSecret(appID, env, tokenType string, secret Secret) (*Secret, error) {
-	url := fmt.Sprintf("%s/v1/secrets/?app_id=%s&env=%s", c.HostURL, appID, env)
+// do sends a request. In read-only mode only GET and HEAD requests are sent.
+// With token_auto_refresh, a request rejected as unauthorized is sent once
+// more if phase_token_file now holds a different token.
+func (c *PhaseClient) do(req *http.Request) (*http.Response, error) {
+	if c.ReadOnly && req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return nil, fmt.Errorf("refusing %s %s: %w", req.Method, req.URL.Path, ErrReadOnly)
+	}
+
+	resp, err := c.send(req)
+	if err == nil && resp.StatusCode == http.StatusUnauthorized && c.tokenFile != "" {
+		return c.retryWithRefreshedToken(req, resp)
+	}
+	return resp, err
+}
+
+// send sends a request and logs its method, URL, status code and duration.
+// Request and response bodies are never logged as they contain secret values.
+func (c *PhaseClient) send(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := c.HTTPClient.Do(req)
+
+	fields := map[string]interface{}{
+		"method":      req.Method,
+		"url":         req.URL.Redacted(),
+		"duration_ms": time.Since(start).Milliseconds(),
+	}
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(req.Context(), "Phase API request failed", fields)
+		return nil, err
+	}
+
+	fields["status_code"] = resp.StatusCode
+	if id := requestID(resp); id != "" {
+		fields["request_id"] = id
+	}
+	tflog.Debug(req.Context(), "Phase API request", fields)
+
+	return resp, nil
+}
+
+// CreateSecret creates a new secret. If batching is enabled, the secret may be
+// created in one request together with others for the same app and environment.
+func (c *PhaseClient) CreateSecret(ctx context.Context, appID, env string, secret Secret) (*Secret, error) {
+	if c.batcher != nil {
+		return c.batcher.Submit(ctx, batchOpCreate, appID, env, secret)
+	}
+
+	createdSecrets, err := c.CreateSecrets(ctx, appID, env, []Secret{secret})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(createdSecrets) == 0 {
+		return nil, fmt.Errorf("no secret created")
+	}
+
+	return &createdSecrets[0], nil
+}
+
+// CreateSecrets creates several secrets in a single request. If only some of
+// them fail, the created secrets are returned along with a *BatchError.
+func (c *PhaseClient) CreateSecrets(ctx context.Context, appID, env string, secrets []Secret) ([]Secret, error) {
+	url := fmt.Sprintf("%s/%s/secrets/?app_id=%s&env=%s", c.HostURL, c.APIVersion, appID, env)
 
 	body, err := json.Marshal(map[string]interface{}{
-		"secrets": []Secret{secret},
+		"secrets": secrets,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, err
 	}
 
-	c.setHeaders(req, tokenType)
+	c.setHeaders(req)
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -65,39 +158,135 @@ func (c *PhaseClient) CreateSecret(appID, env, tokenType string, secret Secret)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to create secret: %s", resp.Status)
+		if batchErr := newBatchError("failed to create secret(s)", resp, responseBody, secretValues(secrets)...); batchErr != nil {
+			return batchErr.Succeeded, batchErr
+		}
+		return nil, newAPIError("failed to create secret(s)", resp, responseBody, secretValues(secrets)...)
 	}
 
 	var createdSecrets []Secret
-	err = json.Unmarshal(responseBody, &createdSecrets)
+	err = decodeJSON("failed to create secret(s)", resp, responseBody, &createdSecrets, secretValues(secrets)...)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(createdSecrets) == 0 {
-		return nil, fmt.Errorf("no secret created")
+	return createdSecrets, nil
+}
+
+// UpsertSecret creates a secret, or updates the existing secret with the same
+// key and path if the API reports a conflict
+func (c *PhaseClient) UpsertSecret(ctx context.Context, appID, env string, secret Secret) (*Secret, error) {
+	createdSecret, err := c.CreateSecret(ctx, appID, env, secret)
+	if err == nil {
+		return createdSecret, nil
 	}
 
-	return &createdSecrets[0], nil
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict {
+		return nil, err
+	}
+
+	existingSecrets, err := c.ReadSecret(ctx, appID, env, secret.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find conflicting secret %q: %w", secret.Key, err)
+	}
+
+	path := secret.Path
+	if path == "" {
+		path = "/"
+	}
+
+	for _, existing := range existingSecrets {
+		if existing.Key == secret.Key && existing.Path == path {
+			secret.ID = existing.ID
+			return c.UpdateSecret(ctx, appID, env, secret)
+		}
+	}
+
+	return nil, fmt.Errorf("secret %q conflicts with an existing secret at path %q that could not be found", secret.Key, path)
 }
 
 // If secretKey is empty, it fetches all secrets for the given app and environment.
-func (c *PhaseClient) ReadSecret(appID, env, secretKey, tokenType string) ([]Secret, error) {
+func (c *PhaseClient) ReadSecret(ctx context.Context, appID, env, secretKey string) ([]Secret, error) {
 	var url string
 	if secretKey != "" {
-		url = fmt.Sprintf("%s/v1/secrets/?app_id=%s&env=%s&key=%s", c.HostURL, appID, env, secretKey)
+		url = fmt.Sprintf("%s/%s/secrets/?app_id=%s&env=%s&key=%s", c.HostURL, c.APIVersion, appID, env, secretKey)
 	} else {
-		url = fmt.Sprintf("%s/v1/secrets/?app_id=%s&env=%s", c.HostURL, appID, env)
+		url = fmt.Sprintf("%s/%s/secrets/?app_id=%s&env=%s", c.HostURL, c.APIVersion, appID, env)
 	}
 
-	req, err := http.NewRequest("GET", url, nil)
+	secrets, err := c.getSecretPages(ctx, url, "failed to read secret(s)")
 	if err != nil {
 		return nil, err
 	}
 
-	c.setHeaders(req, tokenType)
+	if len(secrets) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return secrets, nil
+}
 
-	resp, err := c.HTTPClient.Do(req)
+// ReadSecretAsOf reads secret(s) as they were at the given time. The time is
+// always sent in UTC so it needs no escaping in the query.
+func (c *PhaseClient) ReadSecretAsOf(ctx context.Context, appID, env, secretKey string, asOf time.Time) ([]Secret, error) {
+	url := fmt.Sprintf("%s/%s/secrets/?app_id=%s&env=%s&as_of=%s", c.HostURL, c.APIVersion, appID, env, asOf.UTC().Format(time.RFC3339))
+	if secretKey != "" {
+		url += "&key=" + secretKey
+	}
+
+	secrets, err := c.getSecretPages(ctx, url, "failed to read secret(s)")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(secrets) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return secrets, nil
+}
+
+// UpdateSecret updates an existing secret. If batching is enabled, the secret
+// may be updated in one request together with others for the same app and
+// environment.
+func (c *PhaseClient) UpdateSecret(ctx context.Context, appID, env string, secret Secret) (*Secret, error) {
+	if c.batcher != nil {
+		return c.batcher.Submit(ctx, batchOpUpdate, appID, env, secret)
+	}
+
+	updatedSecrets, err := c.UpdateSecrets(ctx, appID, env, []Secret{secret})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(updatedSecrets) == 0 {
+		return nil, fmt.Errorf("no secret updated")
+	}
+
+	return &updatedSecrets[0], nil
+}
+
+// UpdateSecrets updates several existing secrets in a single request. If only
+// some of them fail, the updated secrets are returned along with a *BatchError.
+func (c *PhaseClient) UpdateSecrets(ctx context.Context, appID, env string, secrets []Secret) ([]Secret, error) {
+	url := fmt.Sprintf("%s/%s/secrets/?app_id=%s&env=%s", c.HostURL, c.APIVersion, appID, env)
+
+	body, err := json.Marshal(map[string]interface{}{
+		"secrets": secrets,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+
+	c.setHeaders(req)
+
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -109,41 +298,214 @@ func (c *PhaseClient) ReadSecret(appID, env, secretKey, tokenType string) ([]Sec
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to read secret(s): %s", resp.Status)
+		if batchErr := newBatchError("failed to update secret(s)", resp, responseBody, secretValues(secrets)...); batchErr != nil {
+			return batchErr.Succeeded, batchErr
+		}
+		return nil, newAPIError("failed to update secret(s)", resp, responseBody, secretValues(secrets)...)
 	}
 
-	var secrets []Secret
-	err = json.Unmarshal(responseBody, &secrets)
+	var updatedSecrets []Secret
+	err = decodeJSON("failed to update secret(s)", resp, responseBody, &updatedSecrets, secretValues(secrets)...)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(secrets) == 0 {
-		return nil, fmt.Errorf("no secrets found")
-	}
+	return updatedSecrets, nil
+}
 
-	return secrets, nil
+// DeleteSecret deletes a secret by its ID
+func (c *PhaseClient) DeleteSecret(ctx context.Context, appID, env, secretID string) error {
+	return c.DeleteSecrets(ctx, appID, env, []string{secretID})
 }
 
-// UpdateSecret updates an existing secret
-func (c *PhaseClient) UpdateSecret(appID, env, tokenType string, secret Secret) (*Secret, error) {
-	url := fmt.Sprintf("%s/v1/secrets/?app_id=%s&env=%s", c.HostURL, appID, env)
+// DeleteSecrets deletes several secrets by their IDs in a single request
+func (c *PhaseClient) DeleteSecrets(ctx context.Context, appID, env string, secretIDs []string) error {
+	url := fmt.Sprintf("%s/%s/secrets/?app_id=%s&env=%s", c.HostURL, c.APIVersion, appID, env)
 
 	body, err := json.Marshal(map[string]interface{}{
-		"secrets": []Secret{secret},
+		"secrets": secretIDs,
 	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+
+	c.setHeaders(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		responseBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("error reading response body: %w", err)
+		}
+		return newAPIError("failed to delete secret(s)", resp, responseBody)
+	}
+
+	return nil
+}
+
+// DeleteSecretsByFilter deletes every secret matching the filter in a single
+// request and returns the deleted secrets, along with the protected secrets
+// that matched but were skipped
+func (c *PhaseClient) DeleteSecretsByFilter(ctx context.Context, appID, env string, filter SecretFilter) ([]Secret, []Secret, error) {
+	secrets, err := c.ReadSecret(ctx, appID, env, "")
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	var matched, skipped []Secret
+	var secretIDs []string
+	for _, secret := range secrets {
+		if filter.Path != "" && secret.Path != filter.Path {
+			continue
+		}
+		if !strings.HasPrefix(secret.Key, filter.KeyPrefix) {
+			continue
+		}
+		if filter.Tag != "" && !slices.Contains(secret.Tags, filter.Tag) {
+			continue
+		}
+		if !filter.IncludeProtected && slices.Contains(secret.Tags, ProtectedTag) {
+			skipped = append(skipped, secret)
+			continue
+		}
+		matched = append(matched, secret)
+		secretIDs = append(secretIDs, secret.ID)
+	}
+
+	if len(secretIDs) == 0 {
+		return nil, skipped, nil
+	}
+
+	if err := c.DeleteSecrets(ctx, appID, env, secretIDs); err != nil {
+		return nil, nil, err
+	}
+
+	return matched, skipped, nil
+}
+
+// ListSecrets lists all secrets for a given app, environment, and path
+func (c *PhaseClient) ListSecrets(ctx context.Context, appID, env, path string) ([]Secret, error) {
+	url := fmt.Sprintf("%s/%s/secrets/?app_id=%s&env=%s&path=%s", c.HostURL, c.APIVersion, appID, env, path)
+
+	return c.getSecretPages(ctx, url, "failed to list secrets")
+}
+
+// getSecretPages fetches secrets from url, following pagination until no next
+// page is left, and returns the secrets from every page
+func (c *PhaseClient) getSecretPages(ctx context.Context, url, errMessage string) ([]Secret, error) {
+	var secrets []Secret
+	seen := make(map[string]bool)
+
+	pageURL := withPageSize(url, secretsPageSize)
+	for pageURL != "" {
+		if seen[pageURL] {
+			return nil, fmt.Errorf("%s: pagination loop detected at %s", errMessage, pageURL)
+		}
+		seen[pageURL] = true
+
+		req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		c.setHeaders(req)
+
+		resp, err := c.do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		responseBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error reading response body: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, newAPIError(errMessage, resp, responseBody)
+		}
+
+		page, next, err := parseSecretsPage(errMessage, pageURL, resp, responseBody)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range page {
+			page[i].Path = normalizePath(page[i].Path)
+		}
+
+		secrets = append(secrets, page...)
+		pageURL = next
+	}
+
+	return secrets, nil
+}
+
+// ReadSecretVersion fetches a single historical version of a secret
+func (c *PhaseClient) ReadSecretVersion(ctx context.Context, appID, env, secretID string, version int) (*SecretVersion, error) {
+	url := fmt.Sprintf("%s/%s/secrets/versions/?app_id=%s&env=%s&id=%s&version=%d", c.HostURL, c.APIVersion, appID, env, secretID, version)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(body))
+	c.setHeaders(req)
+
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
-	c.setHeaders(req, tokenType)
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
 
-	resp, err := c.HTTPClient.Do(req)
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError("failed to read secret version", resp, responseBody)
+	}
+
+	var secretVersion SecretVersion
+	err = decodeJSON("failed to read secret version", resp, responseBody, &secretVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &secretVersion, nil
+}
+
+// CreateServiceToken creates a service token for the given app
+func (c *PhaseClient) CreateServiceToken(ctx context.Context, appID string, serviceToken ServiceToken) (*ServiceToken, error) {
+	url := fmt.Sprintf("%s/%s/service-tokens/?app_id=%s", c.HostURL, c.APIVersion, appID)
+
+	body, err := json.Marshal(serviceToken)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+
+	c.setHeaders(req)
+
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -155,65 +517,377 @@ func (c *PhaseClient) UpdateSecret(appID, env, tokenType string, secret Secret)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to update secret: %s", resp.Status)
+		return nil, newAPIError("failed to create service token", resp, responseBody)
 	}
 
-	var updatedSecrets []Secret
-	err = json.Unmarshal(responseBody, &updatedSecrets)
+	var createdToken ServiceToken
+	err = decodeJSON("failed to create service token", resp, responseBody, &createdToken)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(updatedSecrets) == 0 {
-		return nil, fmt.Errorf("no secret updated")
+	return &createdToken, nil
+}
+
+// ListServiceTokens lists the service tokens of the given app. Token values
+// are not included.
+func (c *PhaseClient) ListServiceTokens(ctx context.Context, appID string) ([]ServiceToken, error) {
+	url := fmt.Sprintf("%s/%s/service-tokens/?app_id=%s", c.HostURL, c.APIVersion, appID)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	return &updatedSecrets[0], nil
+	c.setHeaders(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError("failed to list service tokens", resp, responseBody)
+	}
+
+	var serviceTokens []ServiceToken
+	err = decodeJSON("failed to list service tokens", resp, responseBody, &serviceTokens)
+	if err != nil {
+		return nil, err
+	}
+
+	return serviceTokens, nil
 }
 
-// DeleteSecret deletes a secret by its ID
-func (c *PhaseClient) DeleteSecret(appID, env, secretID, tokenType string) error {
-	url := fmt.Sprintf("%s/v1/secrets/?app_id=%s&env=%s", c.HostURL, appID, env)
+// DeleteServiceToken revokes a service token
+func (c *PhaseClient) DeleteServiceToken(ctx context.Context, appID, serviceTokenID string) error {
+	url := fmt.Sprintf("%s/%s/service-tokens/?app_id=%s&id=%s", c.HostURL, c.APIVersion, appID, serviceTokenID)
 
-	body, err := json.Marshal(map[string]interface{}{
-		"secrets": []string{secretID},
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	c.setHeaders(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		responseBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("error reading response body: %w", err)
+		}
+		return newAPIError("failed to delete service token", resp, responseBody)
+	}
+
+	return nil
+}
+
+// CreateLockbox creates a one-time share of a value and returns its URL
+func (c *PhaseClient) CreateLockbox(ctx context.Context, lockbox Lockbox) (*Lockbox, error) {
+	url := fmt.Sprintf("%s/%s/lockbox/", c.HostURL, c.APIVersion)
+
+	body, err := json.Marshal(lockbox)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+
+	c.setHeaders(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError("failed to create lockbox", resp, responseBody, lockbox.Data)
+	}
+
+	var createdLockbox Lockbox
+	err = decodeJSON("failed to create lockbox", resp, responseBody, &createdLockbox, lockbox.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &createdLockbox, nil
+}
+
+// GetLockbox reads the status of a lockbox. The shared value and URL are not
+// included.
+func (c *PhaseClient) GetLockbox(ctx context.Context, lockboxID string) (*Lockbox, error) {
+	url := fmt.Sprintf("%s/%s/lockbox/?id=%s", c.HostURL, c.APIVersion, lockboxID)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.setHeaders(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError("failed to read lockbox", resp, responseBody)
+	}
+
+	var lockbox Lockbox
+	err = decodeJSON("failed to read lockbox", resp, responseBody, &lockbox)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lockbox, nil
+}
+
+// DeleteLockbox revokes a lockbox so its URL can no longer be opened
+func (c *PhaseClient) DeleteLockbox(ctx context.Context, lockboxID string) error {
+	url := fmt.Sprintf("%s/%s/lockbox/?id=%s", c.HostURL, c.APIVersion, lockboxID)
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	c.setHeaders(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		responseBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("error reading response body: %w", err)
+		}
+		return newAPIError("failed to delete lockbox", resp, responseBody)
+	}
+
+	return nil
+}
+
+// ListApps lists all apps accessible with the configured token
+func (c *PhaseClient) ListApps(ctx context.Context) ([]App, error) {
+	url := fmt.Sprintf("%s/%s/apps/", c.HostURL, c.APIVersion)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.setHeaders(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError("failed to list apps", resp, responseBody)
+	}
+
+	var apps []App
+	err = decodeJSON("failed to list apps", resp, responseBody, &apps)
+	if err != nil {
+		return nil, err
+	}
+
+	return apps, nil
+}
+
+// ListMembers lists the members with access to a given app
+func (c *PhaseClient) ListMembers(ctx context.Context, appID string) ([]Member, error) {
+	url := fmt.Sprintf("%s/%s/members/?app_id=%s", c.HostURL, c.APIVersion, appID)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.setHeaders(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError("failed to list members", resp, responseBody)
+	}
+
+	var members []Member
+	err = decodeJSON("failed to list members", resp, responseBody, &members)
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// ListEnvironments lists all environments for a given app
+func (c *PhaseClient) ListEnvironments(ctx context.Context, appID string) ([]Environment, error) {
+	url := fmt.Sprintf("%s/%s/environments/?app_id=%s", c.HostURL, c.APIVersion, appID)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.setHeaders(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError("failed to list environments", resp, responseBody)
+	}
+
+	var environments []Environment
+	err = decodeJSON("failed to list environments", resp, responseBody, &environments)
+	if err != nil {
+		return nil, err
+	}
+
+	return environments, nil
+}
+
+// GetEnvironmentInheritance returns the environment that env inherits secrets
+// from
+func (c *PhaseClient) GetEnvironmentInheritance(ctx context.Context, appID, env string) (*EnvironmentInheritance, error) {
+	url := fmt.Sprintf("%s/%s/environments/inheritance/?app_id=%s&env=%s", c.HostURL, c.APIVersion, appID, env)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.setHeaders(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError("failed to read environment inheritance", resp, responseBody)
+	}
+
+	var inheritance EnvironmentInheritance
+	err = decodeJSON("failed to read environment inheritance", resp, responseBody, &inheritance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inheritance, nil
+}
+
+// SetEnvironmentInheritance sets the environment that env inherits secrets
+// from. An empty parent removes the inheritance.
+func (c *PhaseClient) SetEnvironmentInheritance(ctx context.Context, appID, env, parent string) error {
+	url := fmt.Sprintf("%s/%s/environments/inheritance/?app_id=%s", c.HostURL, c.APIVersion, appID)
+
+	body, err := json.Marshal(EnvironmentInheritance{
+		Env:          env,
+		InheritsFrom: parent,
 	})
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("DELETE", url, bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(body))
 	if err != nil {
 		return err
 	}
 
-	c.setHeaders(req, tokenType)
+	c.setHeaders(req)
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to delete secret: %s", resp.Status)
+		responseBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("error reading response body: %w", err)
+		}
+		return newAPIError("failed to set environment inheritance", resp, responseBody)
 	}
 
 	return nil
 }
 
-// ListSecrets lists all secrets for a given app, environment, and path
-func (c *PhaseClient) ListSecrets(appID, env, path, tokenType string) ([]Secret, error) {
-	url := fmt.Sprintf("%s/v1/secrets/?app_id=%s&env=%s&path=%s", c.HostURL, appID, env, path)
+// Ping checks that the Phase API is reachable and accepts the configured
+// token, and returns the reported server status
+func (c *PhaseClient) Ping(ctx context.Context) (*Health, error) {
+	url := fmt.Sprintf("%s/%s/health/", c.HostURL, c.APIVersion)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	c.setHeaders(req, tokenType)
+	c.setHeaders(req)
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -225,14 +899,14 @@ func (c *PhaseClient) ListSecrets(appID, env, path, tokenType string) ([]Secret,
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to list secrets: %s", resp.Status)
+		return nil, newAPIError("health check failed", resp, responseBody)
 	}
 
-	var secrets []Secret
-	err = json.Unmarshal(responseBody, &secrets)
+	var health Health
+	err = decodeJSON("health check failed", resp, responseBody, &health)
 	if err != nil {
 		return nil, err
 	}
 
-	return secrets, nil
-}
\ No newline at end of file
+	return &health, nil
+}
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// authHeaderTests pairs each kind of Phase token with the Authorization
+// header it must be sent with
+var authHeaderTests = []struct {
+	name  string
+	token string
+	want  string
+}{
+	{name: "user", token: testToken("pss_user", 1), want: "Bearer User " + strings.Repeat("0f", 32)},
+	{name: "service v1", token: testToken("pss_service", 1), want: "Bearer Service " + strings.Repeat("0f", 32)},
+	{name: "service v2", token: testToken("pss_service", 2), want: "Bearer ServiceAccount " + strings.Repeat("0f", 32)},
+}
+
+func TestAuthHeader(t *testing.T) {
+	for _, tt := range authHeaderTests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokenType, bearer, err := extractTokenInfo(tt.token)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := &PhaseClient{Token: bearer, TokenType: tokenType}
+			if got := client.authHeader(); got != tt.want {
+				t.Errorf("authHeader() = %q, want %q", got, tt.want)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			client.setHeaders(req)
+			if got := req.Header.Get("Authorization"); got != tt.want {
+				t.Errorf("setHeaders set Authorization %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthorizationHeaderSent(t *testing.T) {
+	for _, tt := range authHeaderTests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Values("Authorization")
+				writeJSON(w, []Secret{})
+			}))
+			defer server.Close()
+
+			tokenType, bearer, err := extractTokenInfo(tt.token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			client := &PhaseClient{
+				HostURL:    server.URL,
+				APIVersion: DefaultAPIVersion,
+				HTTPClient: server.Client(),
+				Token:      bearer,
+				TokenType:  tokenType,
+			}
+
+			if _, err := client.ListSecrets(context.Background(), "app", "Development", "/"); err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 1 || got[0] != tt.want {
+				t.Errorf("server received Authorization %q, want exactly %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthorizationHeaderNotReplaced(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("Authorization")
+		writeJSON(w, []Secret{})
+	}))
+	defer server.Close()
+
+	client := &PhaseClient{
+		HostURL:      server.URL,
+		APIVersion:   DefaultAPIVersion,
+		HTTPClient:   server.Client(),
+		Token:        "token",
+		TokenType:    "ServiceAccount",
+		ExtraHeaders: map[string]string{"Authorization": "Bearer other"},
+	}
+
+	if _, err := client.ListSecrets(context.Background(), "app", "Development", "/"); err != nil {
+		t.Fatal(err)
+	}
+	if got != "Bearer ServiceAccount token" {
+		t.Errorf("server received Authorization %q, want the client's token", got)
+	}
+}
+
+// BenchmarkConnectionReuse sends requests from as many concurrent workers as
+// Terraform's default parallelism and reports how many opened a connection
+// rather than reusing an idle one
+func BenchmarkConnectionReuse(b *testing.B) {
+	const workers = 10
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []Secret{})
+	}))
+	defer server.Close()
+
+	for _, idle := range []int{2, DefaultMaxIdleConnsPerHost} {
+		b.Run(fmt.Sprintf("max_idle_conns_per_host=%d", idle), func(b *testing.B) {
+			clearPhaseEnv(b)
+			p := Provider()
+			diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+				"host":                    server.URL,
+				"phase_token":             testToken("pss_service", 2),
+				"rate_limit":              0,
+				"max_idle_conns_per_host": idle,
+			}))
+			if diags.HasError() {
+				b.Fatal(diags)
+			}
+			client := p.Meta().(*PhaseClient)
+
+			var dialed, reused atomic.Int64
+			ctx := httptrace.WithClientTrace(context.Background(), &httptrace.ClientTrace{
+				GotConn: func(info httptrace.GotConnInfo) {
+					if info.Reused {
+						reused.Add(1)
+					} else {
+						dialed.Add(1)
+					}
+				},
+			})
+
+			var next atomic.Int64
+			var wg sync.WaitGroup
+			b.ResetTimer()
+			for i := 0; i < workers; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for next.Add(1) <= int64(b.N) {
+						if _, err := client.ListSecrets(ctx, "app", "Development", "/"); err != nil {
+							b.Error(err)
+							return
+						}
+					}
+				}()
+			}
+			wg.Wait()
+			b.StopTimer()
+
+			b.ReportMetric(float64(dialed.Load())/float64(b.N), "dials/op")
+			b.ReportMetric(float64(reused.Load())/float64(b.N), "reused/op")
+
+			// With an idle connection kept for every worker, no connection is
+			// opened after each worker's first
+			if idle >= workers && dialed.Load() > workers {
+				b.Errorf("opened %d connections for %d workers, want at most one each", dialed.Load(), workers)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+)
+
+// secretsPageSize is the number of secrets requested per page
+const secretsPageSize = 1000
+
+// linkNextPattern matches the next page URL in an RFC 8288 Link header
+var linkNextPattern = regexp.MustCompile(`<([^>]+)>\s*;[^,]*rel="?next"?`)
+
+// secretsPage is the paginated form of a secrets list response
+type secretsPage struct {
+	Results []Secret `json:"results"`
+	Next    *string  `json:"next"`
+}
+
+// withPageSize adds the page_size query parameter to rawURL
+func withPageSize(rawURL string, pageSize int) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	query := u.Query()
+	query.Set("page_size", strconv.Itoa(pageSize))
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
+
+// parseSecretsPage decodes a secrets list response and returns the absolute
+// URL of the next page, or an empty string if this is the last page. Both a
+// bare JSON array and a {"results": [...], "next": "..."} object are accepted,
+// and a Link header with rel="next" is honored for either form.
+func parseSecretsPage(message, pageURL string, resp *http.Response, body []byte) ([]Secret, string, error) {
+	var secrets []Secret
+	var next string
+
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '{' {
+		var page secretsPage
+		if err := decodeJSON(message, resp, trimmed, &page); err != nil {
+			return nil, "", err
+		}
+		secrets = page.Results
+		if page.Next != nil {
+			next = *page.Next
+		}
+	} else if err := decodeJSON(message, resp, body, &secrets); err != nil {
+		return nil, "", err
+	}
+
+	if next == "" {
+		for _, link := range resp.Header.Values("Link") {
+			if match := linkNextPattern.FindStringSubmatch(link); match != nil {
+				next = match[1]
+				break
+			}
+		}
+	}
+
+	if next == "" {
+		return secrets, "", nil
+	}
+
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, "", err
+	}
+	nextURL, err := base.Parse(next)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return secrets, nextURL.String(), nil
+}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// normalizePath canonicalizes a secret path to a single leading slash and no
+// trailing or repeated slashes, so "app", "/app/" and "//app" all become
+// "/app". The root path is "/" and an empty path is left empty.
+func normalizePath(path string) string {
+	if path == "" {
+		return ""
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	return "/" + strings.Join(segments, "/")
+}
+
+// normalizePathStateFunc stores the normalized form of a path in state
+func normalizePathStateFunc(v interface{}) string {
+	return normalizePath(v.(string))
+}
+
+// suppressEquivalentPath suppresses diffs between paths that refer to the same
+// location, treating an empty path as the root path "/"
+func suppressEquivalentPath(k, old, new string, d *schema.ResourceData) bool {
+	return rootedPath(old) == rootedPath(new)
+}
+
+// rootedPath normalizes a path, mapping an empty path to the root path
+func rootedPath(path string) string {
+	if normalized := normalizePath(path); normalized != "" {
+		return normalized
+	}
+	return "/"
+}
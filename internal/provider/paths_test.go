@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestNormalizePath(t *testing.T) {
+	tests := []struct {
+		path   string
+		want   string
+		rooted string
+	}{
+		{path: "", want: "", rooted: "/"},
+		{path: "/", want: "/", rooted: "/"},
+		{path: "/a", want: "/a", rooted: "/a"},
+		{path: "/a/", want: "/a", rooted: "/a"},
+		{path: "a", want: "/a", rooted: "/a"},
+		{path: "a/", want: "/a", rooted: "/a"},
+		{path: "//a//b//", want: "/a/b", rooted: "/a/b"},
+	}
+
+	for _, tt := range tests {
+		if got := normalizePath(tt.path); got != tt.want {
+			t.Errorf("normalizePath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+		if got := rootedPath(tt.path); got != tt.rooted {
+			t.Errorf("rootedPath(%q) = %q, want %q", tt.path, got, tt.rooted)
+		}
+	}
+}
+
+func TestSuppressEquivalentPath(t *testing.T) {
+	tests := []struct {
+		old, new string
+		want     bool
+	}{
+		{old: "/a", new: "/a/", want: true},
+		{old: "/a", new: "a", want: true},
+		{old: "/a", new: "a/", want: true},
+		{old: "/", new: "", want: true},
+		{old: "/a", new: "/b", want: false},
+		{old: "/a", new: "/a/b", want: false},
+		{old: "/", new: "/a", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := suppressEquivalentPath("path", tt.old, tt.new, nil); got != tt.want {
+			t.Errorf("suppressEquivalentPath(%q, %q) = %t, want %t", tt.old, tt.new, got, tt.want)
+		}
+	}
+}
+
+func TestSecretPathDiff(t *testing.T) {
+	fake := newFakePhase(t)
+	r := newTestResource(t, fake.client(), "phase_secret")
+
+	config := func(path string) cty.Value {
+		return r.config(map[string]cty.Value{
+			"app_id": cty.StringVal("app"),
+			"env":    cty.StringVal("Development"),
+			"key":    cty.StringVal("DB_URL"),
+			"value":  cty.StringVal("postgres://"),
+			"path":   cty.StringVal(path),
+		})
+	}
+
+	state := r.create(config("/a"))
+	if got := state.GetAttr("path").AsString(); got != "/a" {
+		t.Fatalf("path in state = %q, want /a", got)
+	}
+
+	for _, path := range []string{"/a", "/a/", "a", "a/", "//a"} {
+		plan := r.plan(state, config(path))
+		requireNoErrors(t, plan.diagnostics)
+		if plan.changed("path") || len(plan.requiresReplace) > 0 {
+			t.Errorf("path %q: planned a change from /a", path)
+		}
+	}
+
+	plan := r.plan(state, config("/b"))
+	requireNoErrors(t, plan.diagnostics)
+	if !plan.changed("path") {
+		t.Errorf("path /b: planned no change from /a")
+	}
+}
@@ -2,12 +2,30 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func Provider() *schema.Provider {
@@ -21,67 +39,558 @@ func Provider() *schema.Provider {
 			},
 			"phase_token": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"PHASE_TOKEN", "PHASE_SERVICE_TOKEN", "PHASE_PAT_TOKEN"}, nil),
 				Description: "The token for authenticating with Phase. Can be a service token or a personal access token (PAT). Can be set with PHASE_TOKEN, PHASE_SERVICE_TOKEN, or PHASE_PAT_TOKEN environment variables.",
 			},
+			"phase_token_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PHASE_TOKEN_FILE", nil),
+				Description: "Path to a file containing the token for authenticating with Phase. Takes precedence over phase_token. Can be set with PHASE_TOKEN_FILE environment variable.",
+			},
+			"token_auto_refresh": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When a request is rejected as unauthorized, reload the token from phase_token_file and send the request once more if the token has changed. Requires phase_token_file.",
+			},
+			"skip_tls_verification": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Skip TLS certificate verification when connecting to the Phase API. Only use this for self-hosted instances with self-signed certificates.",
+			},
+			"ca_certificate": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_certificate_file"},
+				Description:   "A PEM-encoded CA certificate bundle used to verify the Phase API's TLS certificate.",
+			},
+			"ca_certificate_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_certificate"},
+				Description:   "Path to a PEM-encoded CA certificate bundle used to verify the Phase API's TLS certificate.",
+			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PHASE_PROXY_URL", nil),
+				Description: "The URL of an HTTP, HTTPS or SOCKS5 proxy to send requests through. Can be set with PHASE_PROXY_URL environment variable. Defaults to the standard HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.",
+			},
+			"api_version": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          DefaultAPIVersion,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(regexp.MustCompile(`^[A-Za-z0-9._-]+$`), "must be a single path segment such as v1")),
+				Description:      "The version segment of Phase API paths, such as v1 in /v1/secrets/. Only change this to use a different API version of a self-hosted instance.",
+			},
+			"request_timeout": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          DefaultRequestTimeout,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "The timeout in seconds for each attempt of a request to the Phase API, including reading the response. Retries and the waits between them are not counted against it. Set to 0 to disable the timeout.",
+			},
+			"max_idle_conns_per_host": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          DefaultMaxIdleConnsPerHost,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				Description:      "The maximum number of idle connections to the Phase API kept open for reuse. Raise it when running Terraform with higher parallelism.",
+			},
+			"idle_conn_timeout": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          DefaultIdleConnTimeout,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "The time in seconds an idle connection is kept open for reuse. Set to 0 to keep idle connections open indefinitely.",
+			},
+			"max_response_bytes": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          DefaultMaxResponseBytes,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "The maximum size in bytes of a response body from the Phase API. Larger responses fail with an error. Set to 0 to disable the limit.",
+			},
+			"extra_headers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Additional headers sent with every request, for example to authenticate with a proxy in front of a self-hosted Phase instance. They cannot replace the Authorization, Content-Type or User-Agent headers.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"extra_user_agent": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Additional text appended to the User-Agent of every request, for example to identify a CI pipeline in Phase request logs.",
+			},
+			"minimal_user_agent": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Omit the local username and hostname from the User-Agent, sending only the provider version and OS/arch.",
+			},
+			"read_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Refuse every request that would create, update or delete data in Phase. Use this to run plans that detect drift with a token that has write access.",
+			},
+			"default_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "/",
+				Description: "The path of phase_secret resources that do not set path.",
+			},
+			"default_tags": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The tags of phase_secret resources that do not set tags.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"placeholder_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Regular expressions matching secret values that look like placeholders, such as ^CHANGEME$. Managed secrets with a matching value are rejected at plan time.",
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+				},
+			},
+			"cache_reads": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Cache identical read requests in memory for a short time within a single Terraform run. Any write clears the cache.",
+			},
+			"compress_requests": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Gzip request bodies of 8 KiB or more. Only enable this if your Phase instance accepts gzip-encoded requests.",
+			},
+			"rate_limit": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          DefaultRateLimit,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "The maximum number of requests per second sent to the Phase API, including retries. Set to 0 to disable rate limiting.",
+			},
+			"batch_window_ms": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          0,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, MaxBatchWindow)),
+				Description:      "Wait up to this many milliseconds to combine creates and updates of single secrets in the same app and environment into one request. Set to 0 to send each write on its own.",
+			},
+			"retry": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Retry behavior for transient Phase API failures.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_retries": {
+							Type:             schema.TypeInt,
+							Optional:         true,
+							Default:          DefaultMaxRetries,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+							Description:      "The maximum number of times a failed request is retried.",
+						},
+						"retry_wait_min": {
+							Type:             schema.TypeInt,
+							Optional:         true,
+							Default:          DefaultRetryWaitMin,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+							Description:      "The minimum time in seconds to wait between retries.",
+						},
+						"retry_wait_max": {
+							Type:             schema.TypeInt,
+							Optional:         true,
+							Default:          DefaultRetryWaitMax,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+							Description:      "The maximum time in seconds to wait between retries, including waits requested by a Retry-After header.",
+						},
+					},
+				},
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"phase_secret": resourceSecret(),
+			"phase_environment_inheritance": resourceEnvironmentInheritance(),
+			"phase_lockbox":                 resourceLockbox(),
+			"phase_secret":                  resourceSecret(),
+			"phase_secrets":                 resourceSecrets(),
+			"phase_secret_multi_env":        resourceSecretMultiEnv(),
+			"phase_secrets_cleanup":         resourceSecretsCleanup(),
+			"phase_secret_override":         resourceSecretOverride(),
+			"phase_secret_sync":             resourceSecretSync(),
+			"phase_service_token":           resourceServiceToken(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"phase_secrets": dataSourceSecrets(),
+			"phase_app":              dataSourceApp(),
+			"phase_apps":             dataSourceApps(),
+			"phase_environments":     dataSourceEnvironments(),
+			"phase_health":           dataSourceHealth(),
+			"phase_members":          dataSourceMembers(),
+			"phase_secret":           dataSourceSecret(),
+			"phase_secret_paths":     dataSourceSecretPaths(),
+			"phase_secret_version":   dataSourceSecretVersion(),
+			"phase_secrets":          dataSourceSecrets(),
+			"phase_secrets_diff":     dataSourceSecretsDiff(),
+			"phase_secrets_document": dataSourceSecretsDocument(),
+			"phase_secrets_template": dataSourceSecretsTemplate(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	phaseToken := d.Get("phase_token").(string)
+	phaseToken, diags := resolvePhaseToken(d)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// Never include the token itself in the error, as it is a credential
+	if !PssUserPattern.MatchString(phaseToken) && !PssServicePattern.MatchString(phaseToken) {
+		return nil, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Invalid Phase token format",
+			Detail:   "The Phase token must be a user token starting with pss_user:v<version>: or a service token starting with pss_service:v<version>:, followed by four 64-character hex segments. Check that the whole token was copied.",
+		}}
+	}
+
 	host := d.Get("host").(string)
 
-	if host != DefaultHostURL {
-		host = fmt.Sprintf("%s/service/public", host)
+	if d.Get("token_auto_refresh").(bool) && d.Get("phase_token_file").(string) == "" {
+		return nil, diag.Errorf("token_auto_refresh requires phase_token_file, as the token is reloaded from that file")
+	}
+
+	host, err := normalizeHostURL(host)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	var warnings diag.Diagnostics
+	if d.Get("skip_tls_verification").(bool) && strings.HasPrefix(host, "http://") {
+		warnings = append(warnings, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "skip_tls_verification has no effect on an http:// host",
+			Detail:   fmt.Sprintf("The Phase host %s does not use TLS, so requests to it are not encrypted. Use an https:// host, or remove skip_tls_verification.", host),
+		})
+	}
+
+	host = resolveHostURL(host)
+
+	tokenType, bearerToken, err := extractTokenInfo(phaseToken)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	baseTransport, diags := configureTransport(d)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var limitedTransport http.RoundTripper = baseTransport
+	if maxResponseBytes := d.Get("max_response_bytes").(int); maxResponseBytes > 0 {
+		limitedTransport = &responseLimitTransport{
+			base:  limitedTransport,
+			limit: int64(maxResponseBytes),
+		}
+	}
+
+	if rateLimit := d.Get("rate_limit").(int); rateLimit > 0 {
+		// Limit beneath the retry transport so retried attempts also wait
+		limitedTransport = &rateLimitTransport{
+			base:    limitedTransport,
+			limiter: newRateLimiter(rateLimit),
+		}
+	}
+
+	transport, diags := configureRetry(d, limitedTransport)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if d.Get("compress_requests").(bool) {
+		transport = &compressTransport{
+			base:      transport,
+			threshold: DefaultCompressThreshold,
+		}
+	}
+
+	if d.Get("cache_reads").(bool) {
+		transport = newCacheTransport(transport, DefaultCacheTTL)
+	}
+
+	extraHeaders := make(map[string]string)
+	for name, value := range d.Get("extra_headers").(map[string]interface{}) {
+		extraHeaders[name] = value.(string)
 	}
 
-	tokenType, bearerToken := extractTokenInfo(phaseToken)
+	var placeholderPatterns []*regexp.Regexp
+	for _, raw := range d.Get("placeholder_patterns").([]interface{}) {
+		pattern, err := regexp.Compile(raw.(string))
+		if err != nil {
+			return nil, diag.Errorf("invalid placeholder pattern %q: %s", raw.(string), err)
+		}
+		placeholderPatterns = append(placeholderPatterns, pattern)
+	}
 
 	client := &PhaseClient{
 		HostURL:    host,
-		HTTPClient: &http.Client{},
-		Token:      bearerToken,
-		TokenType:  tokenType,
+		APIVersion: d.Get("api_version").(string),
+		HTTPClient: &http.Client{
+			Transport: transport,
+		},
+		Token:               bearerToken,
+		TokenType:           tokenType,
+		ExtraHeaders:        extraHeaders,
+		ExtraUserAgent:      d.Get("extra_user_agent").(string),
+		MinimalUserAgent:    d.Get("minimal_user_agent").(bool),
+		ReadOnly:            d.Get("read_only").(bool),
+		PlaceholderPatterns: placeholderPatterns,
+		DefaultPath:         rootedPath(d.Get("default_path").(string)),
+		DefaultTags:         expandTags(d.Get("default_tags").(*schema.Set)),
+	}
+
+	if d.Get("token_auto_refresh").(bool) {
+		client.tokenFile = d.Get("phase_token_file").(string)
 	}
 
-	return client, nil
+	if batchWindow := d.Get("batch_window_ms").(int); batchWindow > 0 {
+		client.batcher = newSecretBatcher(client, time.Duration(batchWindow)*time.Millisecond)
+	}
+
+	// The token itself is never logged, only its type
+	tflog.Debug(ctx, "Configured Phase provider", map[string]interface{}{
+		"host_url":   host,
+		"token_type": tokenType,
+	})
+
+	return client, warnings
 }
 
-func extractTokenInfo(phaseToken string) (string, string) {
-	// First, check if it's a service token
-	if PssServicePattern.MatchString(phaseToken) {
-		parts := strings.Split(phaseToken, ":")
-		if len(parts) >= 3 {
-			version := parts[1]
-			bearerToken := parts[2]
+// resolvePhaseToken returns the token from phase_token_file if set, otherwise
+// from phase_token. Setting both to different tokens is an error.
+func resolvePhaseToken(d *schema.ResourceData) (string, diag.Diagnostics) {
+	phaseToken := d.Get("phase_token").(string)
+
+	tokenFile := d.Get("phase_token_file").(string)
+	if tokenFile == "" {
+		if phaseToken == "" {
+			return "", diag.Errorf("a Phase token must be set with phase_token or phase_token_file")
+		}
+		return phaseToken, nil
+	}
 
-			// For service tokens with v2
-			if version == "v2" {
-				return "ServiceAccount", bearerToken
-			}
-			return "Service", bearerToken
+	data, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return "", diag.Errorf("failed to read phase_token_file: %s", err)
+	}
+
+	fileToken := strings.TrimSpace(string(data))
+	if fileToken == "" {
+		return "", diag.Errorf("phase_token_file %s is empty", tokenFile)
+	}
+
+	if phaseToken != "" && phaseToken != fileToken {
+		return "", diag.Errorf("phase_token and phase_token_file are both set but contain different tokens, set only one of them")
+	}
+
+	return fileToken, nil
+}
+
+// configureTransport builds the base HTTP transport shared by all requests
+func configureTransport(d *schema.ResourceData) (*http.Transport, diag.Diagnostics) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	tlsConfig := &tls.Config{}
+
+	caPEM := []byte(d.Get("ca_certificate").(string))
+	if caFile := d.Get("ca_certificate_file").(string); caFile != "" {
+		data, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, diag.Errorf("failed to read ca_certificate_file: %s", err)
+		}
+		caPEM = data
+	}
+
+	if len(caPEM) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, diag.Errorf("no valid PEM certificates found in the configured CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if d.Get("skip_tls_verification").(bool) {
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	// The default transport keeps only two idle connections per host, so most
+	// connections would be closed and reopened under Terraform's parallelism
+	maxIdleConnsPerHost := d.Get("max_idle_conns_per_host").(int)
+	transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	transport.MaxIdleConns = max(transport.MaxIdleConns, maxIdleConnsPerHost)
+	transport.IdleConnTimeout = time.Duration(d.Get("idle_conn_timeout").(int)) * time.Second
+
+	transport.Proxy = http.ProxyFromEnvironment
+	if proxyURL := d.Get("proxy_url").(string); proxyURL != "" {
+		parsed, err := url.Parse(proxyURL)
+		if err != nil {
+			return nil, diag.Errorf("invalid proxy_url: %s", err)
+		}
+
+		switch parsed.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, diag.Errorf("invalid proxy_url scheme %q, must be one of http, https or socks5", parsed.Scheme)
+		}
+
+		transport.Proxy = http.ProxyURL(parsed)
+	}
+
+	return transport, nil
+}
+
+// normalizeHostURL canonicalizes a host URL before the API path is resolved.
+// A missing scheme defaults to https, the scheme and host name are lower-cased,
+// a default port is dropped, and repeated and trailing slashes are removed
+// from the path.
+func normalizeHostURL(host string) (string, error) {
+	host = strings.TrimSpace(host)
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+
+	parsed, err := url.Parse(host)
+	if err != nil {
+		return "", fmt.Errorf("invalid host %q: %w", host, err)
+	}
+
+	scheme := strings.ToLower(parsed.Scheme)
+	if scheme != "https" && scheme != "http" {
+		return "", fmt.Errorf("invalid host %q: the scheme must be https or http", host)
+	}
+	if parsed.Hostname() == "" {
+		return "", fmt.Errorf("invalid host %q: no host name", host)
+	}
+	if parsed.User != nil || parsed.RawQuery != "" || parsed.Fragment != "" {
+		return "", fmt.Errorf("invalid host %q: credentials, query strings and fragments are not supported", parsed.Redacted())
+	}
+
+	hostname := strings.ToLower(parsed.Hostname())
+	if strings.Contains(hostname, ":") {
+		// IPv6 addresses must stay bracketed
+		hostname = "[" + hostname + "]"
+	}
+	if port := parsed.Port(); port != "" && !(scheme == "https" && port == "443") && !(scheme == "http" && port == "80") {
+		hostname += ":" + port
+	}
+
+	return scheme + "://" + hostname + strings.TrimSuffix(normalizePath(parsed.Path), "/"), nil
+}
+
+// resolveHostURL returns the API base URL for a host. Self-hosted instances
+// serve the API under SelfHostedAPIPath, which is appended unless the host
+// already ends with it. Trailing slashes are trimmed first.
+func resolveHostURL(host string) string {
+	if isCloudHost(host) {
+		return DefaultHostURL
+	}
+
+	host = strings.TrimRight(host, "/")
+	if strings.HasSuffix(host, SelfHostedAPIPath) {
+		return host
+	}
+	return host + SelfHostedAPIPath
+}
+
+// isCloudHost reports whether host refers to the Phase Cloud API, however it
+// is spelled. The scheme, letter case, default port and trailing slashes are
+// ignored.
+func isCloudHost(host string) bool {
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+
+	parsed, err := url.Parse(host)
+	if err != nil {
+		return false
+	}
+
+	cloud, _ := url.Parse(DefaultHostURL)
+	if !strings.EqualFold(parsed.Hostname(), cloud.Hostname()) {
+		return false
+	}
+
+	switch parsed.Port() {
+	case "", "443":
+	default:
+		return false
+	}
+
+	return strings.Trim(parsed.Path, "/") == ""
+}
+
+// configureRetry wraps the base transport with retry behavior from the retry block
+func configureRetry(d *schema.ResourceData, base http.RoundTripper) (http.RoundTripper, diag.Diagnostics) {
+	maxRetries := DefaultMaxRetries
+	waitMin := DefaultRetryWaitMin
+	waitMax := DefaultRetryWaitMax
+
+	if v, ok := d.GetOk("retry"); ok {
+		retryList := v.([]interface{})
+		if len(retryList) > 0 && retryList[0] != nil {
+			retryMap := retryList[0].(map[string]interface{})
+			maxRetries = retryMap["max_retries"].(int)
+			waitMin = retryMap["retry_wait_min"].(int)
+			waitMax = retryMap["retry_wait_max"].(int)
 		}
 	}
 
-	// Then check if it's a user token
-	if PssUserPattern.MatchString(phaseToken) {
-		parts := strings.Split(phaseToken, ":")
-		if len(parts) >= 3 {
-			return "User", parts[2]
+	if waitMin > waitMax {
+		return nil, diag.Errorf("retry_wait_min (%d) must not be greater than retry_wait_max (%d)", waitMin, waitMax)
+	}
+
+	// request_timeout limits each attempt rather than the whole request, so
+	// that retries and the waits between them are not cut short
+	return &retryTransport{
+		base:       base,
+		maxRetries: maxRetries,
+		waitMin:    time.Duration(waitMin) * time.Second,
+		waitMax:    time.Duration(waitMax) * time.Second,
+		timeout:    time.Duration(d.Get("request_timeout").(int)) * time.Second,
+	}, nil
+}
+
+// extractTokenInfo returns the token type and bearer token for a Phase token.
+// Service token versions are looked up in ServiceTokenTypes, and unknown
+// versions are an error rather than being guessed. User tokens of every
+// version share the User token type.
+func extractTokenInfo(phaseToken string) (string, string, error) {
+	if match := PssServicePattern.FindStringSubmatch(phaseToken); match != nil {
+		version := match[1]
+		tokenType, ok := ServiceTokenTypes[version]
+		if !ok {
+			return "", "", fmt.Errorf("unsupported service token version v%s, upgrade the provider to use this token", version)
 		}
+		return tokenType, match[2], nil
+	}
+
+	if match := PssUserPattern.FindStringSubmatch(phaseToken); match != nil {
+		return "User", match[2], nil
 	}
 
-	return "", phaseToken
+	return "", "", fmt.Errorf("unrecognised Phase token format")
 }
 
 func resourceSecret() *schema.Resource {
@@ -90,12 +599,41 @@ func resourceSecret() *schema.Resource {
 		ReadContext:   resourceSecretRead,
 		UpdateContext: resourceSecretUpdate,
 		DeleteContext: resourceSecretDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSecretImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		CustomizeDiff: customdiff.All(
+			applyProviderDefaults,
+			resolveSecretApp,
+			validateSecretEnv,
+			regenerateSecretValue,
+			rollbackSecretValue,
+			warnActiveOverride,
+			warnKeyCaseCollision,
+			rejectPlaceholderValue,
+			planValueFile,
+			validateValueFormat,
+		),
 
 		Schema: map[string]*schema.Schema{
 			"app_id": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"app", "app_id"},
+			},
+			"app": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the Phase App, resolved to its ID. Use instead of app_id to keep configurations portable between Phase instances.",
 			},
 			"env": {
 				Type:     schema.TypeString,
@@ -103,29 +641,181 @@ func resourceSecret() *schema.Resource {
 				ForceNew: true,
 			},
 			"key": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateSecretKey,
+			},
+			"key_digest": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The digest of the secret key computed by Phase.",
+			},
+			"inherited": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the value is inherited from a parent environment rather than set in this one.",
+			},
+			"inherited_from": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the environment the value is inherited from, if inherited is true.",
 			},
 			"value": {
-				Type:      schema.TypeString,
-				Required:  true,
-				Sensitive: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Sensitive:        true,
+				ExactlyOneOf:     []string{"value", "value_wo", "value_file", "generate", "rollback_to_version"},
+				DiffSuppressFunc: suppressCreateOnlyValue,
+			},
+			"secret_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(uuidPattern, "must be a UUID in its canonical hyphenated form")),
+				Description:      "The ID to create the secret with, for deterministic imports. If not set, Phase assigns one.",
+			},
+			"prevent_destroy_on_server": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Refuse to delete the secret, including when it is replaced, unless the PHASE_ALLOW_PROTECTED_DESTROY environment variable is set to true. phase_secrets_cleanup skips the secret too.",
+			},
+			"verify_before_delete": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Before deleting the secret, check that its ID still belongs to the key and path in state, and fail instead of deleting otherwise. A secret that no longer exists is removed from state.",
+			},
+			"create_only": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"rollback_to_version"},
+				Description:   "Fail instead of updating an existing secret with the same key on create, and never change the value once the secret exists. Use this for secrets that Terraform should seed but not overwrite.",
+			},
+			"value_format": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{ValueFormatURL, ValueFormatJSON, ValueFormatBase64, ValueFormatUUID}, false)),
+				Description:      "The format the value must conform to: url, json, base64 or uuid. The value is checked at plan time.",
+			},
+			"value_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path of a local file whose contents are used as the secret value. The file is read on apply, and changes to its contents are detected at plan time.",
+			},
+			"value_wo": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				WriteOnly:    true,
+				RequiredWith: []string{"value_wo_version"},
+				Description:  "A write-only secret value that is sent to Phase but never stored in state. Requires Terraform 1.11 or later.",
+			},
+			"value_wo_version": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				RequiredWith:     []string{"value_wo"},
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				Description:      "A version number for value_wo. Change it to send an updated write-only value to Phase.",
+			},
+			"rollback_to_version": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				Description:      "Restore the value of a previous version of an existing secret instead of setting value. The value is only restored when this changes.",
+			},
+			"generate": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Generate a random value for the secret instead of setting value.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{GenerateTypeHex, GenerateTypeBase64, GenerateTypeAlphanumeric}, false)),
+							Description:      "The type of value to generate: hex, base64 or alphanumeric.",
+						},
+						"length": {
+							Type:             schema.TypeInt,
+							Optional:         true,
+							Default:          32,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 4096)),
+							Description:      "The length of the generated value.",
+						},
+						"only_if_missing": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Adopt the existing value instead of generating one if the key already exists.",
+						},
+					},
+				},
 			},
 			"comment": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ConflictsWith:    []string{"comment_template"},
+				DiffSuppressFunc: suppressCommentDiff,
+			},
+			"comment_template": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateCommentTemplate,
+				Description:      "A template rendered into comment on every create and update. Supports the {{key}}, {{path}}, {{env}}, {{timestamp}} and {{workspace}} placeholders.",
+			},
+			"ignore_comment_changes": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Only use comment or comment_template when the secret is created, and keep the comment set in Phase on later updates.",
+			},
+			"expires_at": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+				DiffSuppressFunc: suppressEquivalentTime,
+				Description:      "The time the secret expires, in RFC 3339 format such as 2025-01-31T00:00:00Z.",
+			},
+			"tags": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Description: "Tags to attach to the secret. Defaults to the provider's default_tags.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"tags_mode": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          TagsModeExact,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{TagsModeExact, TagsModeMerge}, false)),
+				Description:      "How tags are managed: exact replaces the secret's tags with the configured ones, merge only adds and removes configured tags and keeps tags added outside Terraform.",
 			},
 			"path": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "/",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				StateFunc:        normalizePathStateFunc,
+				DiffSuppressFunc: suppressEquivalentPath,
+				Description:      "The path of the secret. Defaults to the provider's default_path, or / if that is not set.",
 			},
 			"override": {
-				Type:     schema.TypeSet,
-				Optional: true,
-				MaxItems: 1,
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Personal Secret Overrides for the secret. A block without member_id is the override for the authenticated user.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
+						"member_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The ID of the member the override applies to. Defaults to the authenticated user.",
+						},
 						"value": {
 							Type:      schema.TypeString,
 							Required:  true,
@@ -146,33 +836,110 @@ func resourceSecretCreate(ctx context.Context, d *schema.ResourceData, meta inte
 	client := meta.(*PhaseClient)
 
 	secret := Secret{
-		Key:     d.Get("key").(string),
-		Value:   d.Get("value").(string),
-		Comment: d.Get("comment").(string),
-		Path:    d.Get("path").(string),
-	}
-
-	if v, ok := d.GetOk("override"); ok {
-		overrideSet := v.(*schema.Set).List()
-		if len(overrideSet) > 0 {
-			overrideMap := overrideSet[0].(map[string]interface{})
-			secret.Override = &SecretOverride{
-				Value:    overrideMap["value"].(string),
-				IsActive: overrideMap["is_active"].(bool),
-			}
-		}
+		Key:       d.Get("key").(string),
+		Value:     d.Get("value").(string),
+		Comment:   secretComment(d),
+		Path:      normalizePath(d.Get("path").(string)),
+		Tags:      protectionTags(expandTags(d.Get("tags").(*schema.Set)), d.Get("prevent_destroy_on_server").(bool)),
+		ExpiresAt: d.Get("expires_at").(string),
 	}
 
+	override, overrides, err := expandOverrides(d.Get("override").(*schema.Set))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	secret.Override = override
+	secret.Overrides = overrides
+
 	appID := d.Get("app_id").(string)
 	env := d.Get("env").(string)
 
-	createdSecret, err := client.CreateSecret(appID, env, fmt.Sprintf("Bearer %s", client.TokenType), secret)
+	// The app name may not have been known at plan time
+	if appID == "" {
+		app, err := findAppByName(ctx, client, d.Get("app").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		appID = app.ID
+		d.Set("app_id", appID)
+	}
+
+	if value, ok := writeOnlyValue(d); ok {
+		secret.Value = value
+	}
+
+	if valueFile := d.Get("value_file").(string); valueFile != "" {
+		data, err := os.ReadFile(valueFile)
+		if err != nil {
+			return diag.Errorf("failed to read value_file: %s", err)
+		}
+		secret.Value = string(data)
+	}
+
+	if generate, ok := expandGenerate(d); ok {
+		if generate["only_if_missing"].(bool) {
+			existing, err := client.ReadSecret(ctx, appID, env, secret.Key)
+			if err == nil {
+				for _, s := range existing {
+					if s.Path == secret.Path {
+						d.SetId(s.ID)
+						return resourceSecretRead(ctx, d, meta)
+					}
+				}
+			}
+		}
+
+		value, err := generateSecretValue(generate["type"].(string), generate["length"].(int))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		secret.Value = value
+	}
+
+	requestedID := d.Get("secret_id").(string)
+	secret.ID = requestedID
+
+	// Only secrets at the same path can collide by case, so there is no need
+	// to list the whole environment
+	var diags diag.Diagnostics
+	atPath, err := client.ListSecrets(ctx, appID, env, rootedPath(secret.Path))
+	if err == nil {
+		if collision := caseCollision(atPath, secret.Key, rootedPath(secret.Path)); collision != "" {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Secret key %s differs only by case from existing key %s", secret.Key, collision),
+				Detail:   fmt.Sprintf("Both %s and %s now exist at path %s. Keys that differ only by case are easily confused, so check that this is intended.", secret.Key, collision, secret.Path),
+			})
+		}
+	}
+
+	// An existing secret keeps its own ID, so it is never updated in place of
+	// creating one with the requested ID
+	var createdSecret *Secret
+	if d.Get("create_only").(bool) || requestedID != "" {
+		createdSecret, err = client.CreateSecret(ctx, appID, env, secret)
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+			if requestedID != "" {
+				if currentSecretIDIn(atPath, secret.Key, rootedPath(secret.Path)) != "" {
+					return append(diags, diag.Errorf("cannot create secret %q with ID %s: a secret with that key already exists at path %q", secret.Key, requestedID, secret.Path)...)
+				}
+				return append(diags, diag.Errorf("cannot create secret %q with ID %s: the ID is already used by another secret", secret.Key, requestedID)...)
+			}
+			return append(diags, diag.Errorf("secret %q already exists at path %q and create_only is set, so it will not be overwritten. Import it or unset create_only to manage its value.", secret.Key, secret.Path)...)
+		}
+	} else {
+		createdSecret, err = client.UpsertSecret(ctx, appID, env, secret)
+	}
 	if err != nil {
-		return diag.FromErr(err)
+		return append(diags, diag.FromErr(err)...)
 	}
 
 	d.SetId(createdSecret.ID)
-	return resourceSecretRead(ctx, d, meta)
+	if requestedID != "" && createdSecret.ID != requestedID {
+		return append(diags, diag.Errorf("secret %q was created with ID %s instead of the requested ID %s. This Phase instance does not support client-specified IDs, so unset secret_id.", secret.Key, createdSecret.ID, requestedID)...)
+	}
+	return append(diags, resourceSecretRead(ctx, d, meta)...)
 }
 
 func resourceSecretRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -181,66 +948,724 @@ func resourceSecretRead(ctx context.Context, d *schema.ResourceData, meta interf
 	appID := d.Get("app_id").(string)
 	env := d.Get("env").(string)
 	secretKey := d.Get("key").(string)
+	path := normalizePath(d.Get("path").(string))
 
-	secrets, err := client.ReadSecret(appID, env, secretKey, fmt.Sprintf("Bearer %s", client.TokenType))
-	if err != nil {
+	secrets, err := client.ReadSecret(ctx, appID, env, secretKey)
+	if err != nil && !isNotFound(err) {
 		return diag.FromErr(err)
 	}
 
-	if len(secrets) == 0 {
-		return diag.Errorf("No secrets found")
+	// The same key can exist under several paths, so only use the configured one
+	var secret *Secret
+	for i := range secrets {
+		if secrets[i].Path == path {
+			secret = &secrets[i]
+			break
+		}
 	}
 
-	// If a specific key was provided, use the first (and should be only) secret
-	secret := secrets[0]
+	if secret == nil {
+		log.Printf("[WARN] Secret %s at path %s not found in %s, removing from state", secretKey, path, env)
+		d.SetId("")
+		return nil
+	}
 
-	d.SetId(secret.Key) // Use the key as the ID
+	d.SetId(secret.ID)
+	d.Set("secret_id", secret.ID)
 	d.Set("key", secret.Key)
 	d.Set("comment", secret.Comment)
+	if d.Get("tags_mode").(string) == TagsModeMerge {
+		// Tags added outside Terraform are not drift in merge mode
+		d.Set("tags", managedTags(secret.Tags, expandTags(d.Get("tags").(*schema.Set))))
+	} else {
+		d.Set("tags", protectionTags(secret.Tags, false))
+	}
 	d.Set("path", secret.Path)
+	d.Set("key_digest", secret.KeyDigest)
+	d.Set("expires_at", secret.ExpiresAt)
+	d.Set("inherited", secret.Inherited)
+	d.Set("inherited_from", secret.InheritedFrom)
+
+	var diags diag.Diagnostics
+	if secret.Override != nil && secret.Override.IsActive && secret.Override.Value != secret.Value {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Secret %s has an active Personal Secret Override", secret.Key),
+			Detail:   "The effective value of this secret comes from an active Personal Secret Override and differs from its stored value. Changes to value will not take effect for this user until the override is deactivated.",
+		})
+	}
+
+	// Write-only values must never be read back into state
+	if _, ok := d.GetOk("value_wo_version"); ok {
+		return diags
+	}
 
 	if secret.Override != nil && secret.Override.IsActive {
 		d.Set("value", secret.Override.Value)
-		d.Set("override", []interface{}{
-			map[string]interface{}{
-				"value":     secret.Override.Value,
-				"is_active": secret.Override.IsActive,
-			},
-		})
 	} else {
 		d.Set("value", secret.Value)
-		d.Set("override", []interface{}{})
+	}
+	d.Set("override", flattenOverrides(secret))
+
+	return diags
+}
+
+// applyProviderDefaults plans the provider's default_path and default_tags for
+// a secret that does not set path or tags itself
+func applyProviderDefaults(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	client := meta.(*PhaseClient)
+	config := d.GetRawConfig()
+
+	if config.GetAttr("path").IsNull() {
+		if err := d.SetNew("path", client.DefaultPath); err != nil {
+			return err
+		}
+	}
+
+	if config.GetAttr("tags").IsNull() {
+		if err := d.SetNew("tags", client.DefaultTags); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-func resourceSecretUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+// resolveSecretApp looks up the ID of the app named by app at plan time, so
+// that later checks can use it. The secret is only replaced if the name
+// resolves to a different app.
+func resolveSecretApp(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChange("app") {
+		return nil
+	}
+	if !d.NewValueKnown("app") {
+		return d.SetNewComputed("app_id")
+	}
+
+	name := d.Get("app").(string)
+	if name == "" {
+		return nil
+	}
+
+	app, err := findAppByName(ctx, meta.(*PhaseClient), name)
+	if err != nil {
+		return err
+	}
+
+	return d.SetNew("app_id", app.ID)
+}
+
+// validateSecretEnv checks at plan time that env exists in the app. The check
+// is skipped when the app or env is not yet known or the API is unreachable.
+func validateSecretEnv(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChange("env") && !d.HasChange("app_id") {
+		return nil
+	}
+	if !d.NewValueKnown("app_id") || !d.NewValueKnown("env") {
+		return nil
+	}
+
 	client := meta.(*PhaseClient)
 
-	secret := Secret{
-		ID:      d.Id(),
-		Key:     d.Get("key").(string),
-		Value:   d.Get("value").(string),
-		Comment: d.Get("comment").(string),
-		Path:    d.Get("path").(string),
-	}
-
-	if v, ok := d.GetOk("override"); ok {
-		overrideSet := v.(*schema.Set).List()
-		if len(overrideSet) > 0 {
-			overrideMap := overrideSet[0].(map[string]interface{})
-			secret.Override = &SecretOverride{
-				Value:    overrideMap["value"].(string),
-				IsActive: overrideMap["is_active"].(bool),
+	appID := d.Get("app_id").(string)
+	env := d.Get("env").(string)
+
+	environments, err := client.ListEnvironments(ctx, appID)
+	if err != nil {
+		log.Printf("[WARN] Skipping env validation for app %s: %s", appID, err)
+		return nil
+	}
+
+	var names []string
+	for _, environment := range environments {
+		if strings.EqualFold(environment.Name, env) {
+			return nil
+		}
+		names = append(names, environment.Name)
+	}
+
+	return fmt.Errorf("environment %q does not exist in app %s, valid environments are: %s", env, appID, strings.Join(names, ", "))
+}
+
+// regenerateSecretValue marks value as unknown when the generate settings of an
+// existing secret change, so that a new value is generated on apply
+func regenerateSecretValue(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("generate") || d.Get("create_only").(bool) {
+		return nil
+	}
+	if !d.GetRawConfig().GetAttr("value").IsNull() {
+		return nil
+	}
+	if generate, ok := d.GetOk("generate"); !ok || len(generate.([]interface{})) == 0 {
+		return nil
+	}
+
+	return d.SetNewComputed("value")
+}
+
+// warnKeyCaseCollision logs a warning when a new or renamed key differs only by
+// case from an existing key at the same path. CustomizeDiff cannot return
+// warning diagnostics, so resourceSecretCreate also reports the collision.
+func warnKeyCaseCollision(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChange("key") && !d.HasChange("path") {
+		return nil
+	}
+	for _, attr := range []string{"app_id", "env", "key", "path"} {
+		if !d.NewValueKnown(attr) {
+			return nil
+		}
+	}
+
+	client := meta.(*PhaseClient)
+
+	appID := d.Get("app_id").(string)
+	env := d.Get("env").(string)
+	key := d.Get("key").(string)
+	path := normalizePath(d.Get("path").(string))
+
+	secrets, err := client.ListSecrets(ctx, appID, env, rootedPath(path))
+	if err != nil {
+		if !isNotFound(err) {
+			log.Printf("[WARN] Skipping key case collision check for app %s: %s", appID, err)
+		}
+		return nil
+	}
+
+	if existing := caseCollision(secrets, key, rootedPath(path)); existing != "" {
+		log.Printf("[WARN] Secret key %s differs only by case from existing key %s at path %s", key, existing, path)
+	}
+
+	return nil
+}
+
+// rejectPlaceholderValue fails the plan when value or value_wo matches one of
+// the provider's placeholder patterns
+func rejectPlaceholderValue(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	client := meta.(*PhaseClient)
+	if len(client.PlaceholderPatterns) == 0 {
+		return nil
+	}
+
+	key := d.Get("key").(string)
+
+	if d.HasChange("value") && d.NewValueKnown("value") {
+		if err := checkPlaceholderValue(client, key, d.Get("value").(string)); err != nil {
+			return err
+		}
+	}
+
+	raw := d.GetRawConfig().GetAttr("value_wo")
+	if raw.IsKnown() && !raw.IsNull() {
+		return checkPlaceholderValue(client, key, raw.AsString())
+	}
+
+	return nil
+}
+
+// checkPlaceholderValue returns an error if value matches a placeholder
+// pattern. The value itself is never included in the error.
+func checkPlaceholderValue(client *PhaseClient, key, value string) error {
+	for _, pattern := range client.PlaceholderPatterns {
+		if pattern.MatchString(value) {
+			return fmt.Errorf("the value of secret %s matches placeholder pattern %q, set a real value or remove the pattern from placeholder_patterns", key, pattern.String())
+		}
+	}
+	return nil
+}
+
+// caseCollision returns the key of a secret at path that differs from key only
+// by case, or an empty string if there is none
+func caseCollision(secrets []Secret, key, path string) string {
+	for _, secret := range secrets {
+		if secret.Path == path && secret.Key != key && strings.EqualFold(secret.Key, key) {
+			return secret.Key
+		}
+	}
+	return ""
+}
+
+// rollbackSecretValue marks value as unknown when rollback_to_version changes,
+// so that the restored value is read back after apply. Once applied, the
+// value is no longer pinned and later changes made outside Terraform are
+// accepted until rollback_to_version is changed again.
+func rollbackSecretValue(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if _, ok := d.GetOk("rollback_to_version"); !ok || !d.HasChange("rollback_to_version") {
+		return nil
+	}
+	if d.Id() == "" {
+		return fmt.Errorf("rollback_to_version can only be set on an existing secret")
+	}
+
+	return d.SetNewComputed("value")
+}
+
+// commentTemplatePlaceholder matches a {{name}} placeholder in comment_template
+var commentTemplatePlaceholder = regexp.MustCompile(`{{\s*([^{}]*?)\s*}}`)
+
+// secretComment returns comment_template rendered for the secret if it is
+// set, and comment otherwise
+func secretComment(d *schema.ResourceData) string {
+	template := d.Get("comment_template").(string)
+	if template == "" {
+		return d.Get("comment").(string)
+	}
+
+	workspace := os.Getenv("TF_WORKSPACE")
+	if workspace == "" {
+		workspace = "default"
+	}
+
+	values := map[string]string{
+		"key":       d.Get("key").(string),
+		"path":      rootedPath(d.Get("path").(string)),
+		"env":       d.Get("env").(string),
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+		"workspace": workspace,
+	}
+
+	return commentTemplatePlaceholder.ReplaceAllStringFunc(template, func(match string) string {
+		return values[commentTemplatePlaceholder.FindStringSubmatch(match)[1]]
+	})
+}
+
+// validateCommentTemplate checks that comment_template only uses known
+// placeholders
+func validateCommentTemplate(v interface{}, path cty.Path) diag.Diagnostics {
+	for _, match := range commentTemplatePlaceholder.FindAllStringSubmatch(v.(string), -1) {
+		switch match[1] {
+		case "key", "path", "env", "timestamp", "workspace":
+		default:
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Unknown comment template placeholder",
+				Detail:        fmt.Sprintf("%q is not a known placeholder, use one of {{key}}, {{path}}, {{env}}, {{timestamp}} or {{workspace}}.", match[0]),
+				AttributePath: path,
+			}}
+		}
+	}
+	return nil
+}
+
+// suppressCreateOnlyValue ignores value changes to existing secrets when
+// create_only is set
+func suppressCreateOnlyValue(k, old, new string, d *schema.ResourceData) bool {
+	return d.Id() != "" && d.Get("create_only").(bool)
+}
+
+// suppressCommentDiff ignores comment changes when ignore_comment_changes is
+// set, and the difference between the rendered comment in state and the unset
+// comment in config when comment_template is used
+func suppressCommentDiff(k, old, new string, d *schema.ResourceData) bool {
+	if d.Id() != "" && d.Get("ignore_comment_changes").(bool) {
+		return true
+	}
+	return new == "" && d.Get("comment_template").(string) != ""
+}
+
+// planValueFile marks value as unknown when the contents of value_file differ
+// from the value in state, so that the file is read again on apply. The check
+// is deferred to apply if the file does not exist yet.
+func planValueFile(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	valueFile := d.Get("value_file").(string)
+	if valueFile == "" || !d.NewValueKnown("value_file") {
+		return nil
+	}
+	if d.Id() != "" && d.Get("create_only").(bool) {
+		return nil
+	}
+
+	data, err := os.ReadFile(valueFile)
+	if err != nil {
+		log.Printf("[WARN] Could not read value_file during plan, it will be read on apply: %s", err)
+		return d.SetNewComputed("value")
+	}
+
+	if err := checkPlaceholderValue(meta.(*PhaseClient), d.Get("key").(string), string(data)); err != nil {
+		return err
+	}
+
+	if format := d.Get("value_format").(string); format != "" {
+		if err := checkValueFormat(format, string(data)); err != nil {
+			return fmt.Errorf("the contents of value_file for secret %s must be in %s format: %s", d.Get("key").(string), format, err)
+		}
+	}
+
+	old, _ := d.GetChange("value")
+	if d.Id() == "" || d.HasChange("value_file") || old.(string) != string(data) {
+		return d.SetNewComputed("value")
+	}
+
+	return nil
+}
+
+// writeOnlyValue returns value_wo from the configuration if it is set
+func writeOnlyValue(d *schema.ResourceData) (string, bool) {
+	raw, diags := d.GetRawConfigAt(cty.GetAttrPath("value_wo"))
+	if diags.HasError() || !raw.Type().Equals(cty.String) || raw.IsNull() || !raw.IsKnown() {
+		return "", false
+	}
+
+	return raw.AsString(), true
+}
+
+// warnActiveOverride logs a warning when value changes while an active
+// Personal Secret Override means the new value will not take effect.
+// CustomizeDiff cannot return warning diagnostics, so resourceSecretRead also
+// reports active overrides to the user.
+func warnActiveOverride(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("value") {
+		return nil
+	}
+
+	oldOverride, _ := d.GetChange("override")
+	for _, raw := range oldOverride.(*schema.Set).List() {
+		override := raw.(map[string]interface{})
+		if override["member_id"].(string) != "" {
+			continue
+		}
+		if override["is_active"].(bool) && override["value"].(string) != d.Get("value").(string) {
+			log.Printf("[WARN] Secret %s has an active Personal Secret Override, the configured value will not be the effective value", d.Get("key").(string))
+		}
+	}
+
+	return nil
+}
+
+// expandOverrides splits override blocks into the authenticated user's
+// override and overrides scoped to other members
+func expandOverrides(set *schema.Set) (*SecretOverride, []SecretOverride, error) {
+	var personal *SecretOverride
+	var members []SecretOverride
+	seen := make(map[string]bool)
+
+	for _, raw := range set.List() {
+		m := raw.(map[string]interface{})
+		override := SecretOverride{
+			MemberID: m["member_id"].(string),
+			Value:    m["value"].(string),
+			IsActive: m["is_active"].(bool),
+		}
+
+		if override.MemberID == "" {
+			if personal != nil {
+				return nil, nil, fmt.Errorf("only one override block may omit member_id")
 			}
+			personal = &override
+			continue
+		}
+
+		if seen[override.MemberID] {
+			return nil, nil, fmt.Errorf("duplicate override for member %q", override.MemberID)
 		}
+		seen[override.MemberID] = true
+		members = append(members, override)
+	}
+
+	return personal, members, nil
+}
+
+// expandTags converts a set of tags into a sorted list, so tags are always
+// sent to the API in the same order
+func expandTags(set *schema.Set) []string {
+	var tags []string
+	for _, tag := range set.List() {
+		tags = append(tags, tag.(string))
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// managedTags returns the tags of a secret that are also configured, so tags
+// added outside Terraform are ignored
+func managedTags(current, configured []string) []string {
+	var tags []string
+	for _, tag := range current {
+		if slices.Contains(configured, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// protectionTags returns tags with ProtectedTag added if protected is set, or
+// removed otherwise
+func protectionTags(tags []string, protected bool) []string {
+	tags = slices.DeleteFunc(slices.Clone(tags), func(tag string) bool { return tag == ProtectedTag })
+	if protected {
+		tags = append(tags, ProtectedTag)
+		sort.Strings(tags)
+	}
+	return tags
+}
+
+// mergeTags adds the configured tags to the current tags of a secret and
+// removes the ones that were previously configured but no longer are
+func mergeTags(current, previous, configured []string) []string {
+	tags := slices.Clone(configured)
+	for _, tag := range current {
+		removed := slices.Contains(previous, tag) && !slices.Contains(configured, tag)
+		if !removed && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// flattenOverrides maps the overrides on a secret back to override blocks
+func flattenOverrides(secret *Secret) []interface{} {
+	var overrides []interface{}
+
+	if secret.Override != nil && secret.Override.IsActive {
+		overrides = append(overrides, map[string]interface{}{
+			"member_id": "",
+			"value":     secret.Override.Value,
+			"is_active": secret.Override.IsActive,
+		})
+	}
+
+	for _, override := range secret.Overrides {
+		overrides = append(overrides, map[string]interface{}{
+			"member_id": override.MemberID,
+			"value":     override.Value,
+			"is_active": override.IsActive,
+		})
+	}
+
+	return overrides
+}
+
+// expandGenerate returns the generate block if it is set and value is not
+// explicitly configured
+func expandGenerate(d *schema.ResourceData) (map[string]interface{}, bool) {
+	if !d.GetRawConfig().GetAttr("value").IsNull() {
+		return nil, false
+	}
+
+	v, ok := d.GetOk("generate")
+	if !ok {
+		return nil, false
+	}
+
+	generateList := v.([]interface{})
+	if len(generateList) == 0 || generateList[0] == nil {
+		return nil, false
+	}
+
+	return generateList[0].(map[string]interface{}), true
+}
+
+// keyDigestImportPrefix marks the last segment of an import ID as a key digest
+// rather than a key. Keys cannot contain a colon, so the forms never overlap.
+const keyDigestImportPrefix = "digest:"
+
+// resourceSecretImport imports a secret using an ID of the form
+// app_id/env/path/key, where path may be omitted for secrets at the root. The
+// key may be replaced by digest:<key_digest> to import by key digest.
+func resourceSecretImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) < 3 {
+		return nil, fmt.Errorf("invalid import ID %q, expected app_id/env/path/key or app_id/env/key", d.Id())
+	}
+
+	appID := parts[0]
+	env := parts[1]
+	key := parts[len(parts)-1]
+	path := normalizePath("/" + strings.Join(parts[2:len(parts)-1], "/"))
+
+	if appID == "" || env == "" || key == "" {
+		return nil, fmt.Errorf("invalid import ID %q, app_id, env and key must not be empty", d.Id())
+	}
+
+	if digest, ok := strings.CutPrefix(key, keyDigestImportPrefix); ok {
+		var err error
+		key, err = findSecretKeyByDigest(ctx, meta.(*PhaseClient), appID, env, path, digest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import secret %q: %w", d.Id(), err)
+		}
+	}
+
+	d.Set("app_id", appID)
+	d.Set("env", env)
+	d.Set("path", path)
+	d.Set("key", key)
+
+	importID := d.Id()
+
+	diags := resourceSecretRead(ctx, d, meta)
+	if diags.HasError() {
+		return nil, fmt.Errorf("failed to import secret %q: %s", importID, diags[0].Summary)
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("failed to import secret %q: secret not found", importID)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// findSecretKeyByDigest returns the key of the only secret at path with the
+// given key digest
+func findSecretKeyByDigest(ctx context.Context, client *PhaseClient, appID, env, path, digest string) (string, error) {
+	if digest == "" {
+		return "", fmt.Errorf("key digest must not be empty")
+	}
+
+	secrets, err := client.ReadSecret(ctx, appID, env, "")
+	if err != nil && !isNotFound(err) {
+		return "", err
+	}
+
+	var keys []string
+	for _, secret := range secrets {
+		if secret.Path == path && secret.KeyDigest == digest {
+			keys = append(keys, secret.Key)
+		}
+	}
+
+	switch len(keys) {
+	case 0:
+		return "", fmt.Errorf("no secret found with key digest %q at path %q", digest, path)
+	case 1:
+		return keys[0], nil
+	default:
+		return "", fmt.Errorf("found %d secrets with key digest %q at path %q: %s", len(keys), digest, path, strings.Join(keys, ", "))
+	}
+}
+
+func resourceSecretUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	secret := Secret{
+		ID:        d.Id(),
+		Key:       d.Get("key").(string),
+		Value:     d.Get("value").(string),
+		Comment:   secretComment(d),
+		Path:      normalizePath(d.Get("path").(string)),
+		Tags:      expandTags(d.Get("tags").(*schema.Set)),
+		ExpiresAt: d.Get("expires_at").(string),
+	}
+
+	override, overrides, err := expandOverrides(d.Get("override").(*schema.Set))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	secret.Override = override
+	secret.Overrides = overrides
+
+	// Keep the comment last read from Phase, which may have been edited there
+	if d.Get("ignore_comment_changes").(bool) {
+		secret.Comment = d.Get("comment").(string)
 	}
 
 	appID := d.Get("app_id").(string)
 	env := d.Get("env").(string)
 
-	_, err := client.UpdateSecret(appID, env, fmt.Sprintf("Bearer %s", client.TokenType), secret)
+	// The write-only value is never in state, so always send it from config
+	if value, ok := writeOnlyValue(d); ok {
+		secret.Value = value
+	}
+
+	if valueFile := d.Get("value_file").(string); valueFile != "" {
+		data, err := os.ReadFile(valueFile)
+		if err != nil {
+			return diag.Errorf("failed to read value_file: %s", err)
+		}
+		secret.Value = string(data)
+	}
+
+	if generate, ok := expandGenerate(d); ok && d.HasChange("generate") {
+		value, err := generateSecretValue(generate["type"].(string), generate["length"].(int))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		secret.Value = value
+	}
+
+	if version, ok := d.GetOk("rollback_to_version"); ok && d.HasChange("rollback_to_version") {
+		secretVersion, err := client.ReadSecretVersion(ctx, appID, env, d.Id(), version.(int))
+		if isNotFound(err) {
+			return diag.Errorf("cannot roll back: version %d of secret %q does not exist", version.(int), secret.Key)
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		secret.Value = secretVersion.Value
+	}
+
+	// With create_only the value is never changed after creation, so send
+	// the value currently stored in Phase
+	if d.Get("create_only").(bool) {
+		oldKey, _ := d.GetChange("key")
+		existing, err := client.ReadSecret(ctx, appID, env, oldKey.(string))
+		if err != nil && !isNotFound(err) {
+			return diag.FromErr(err)
+		}
+		found := false
+		for _, current := range existing {
+			if current.ID == secret.ID {
+				secret.Value = current.Value
+				found = true
+				break
+			}
+		}
+		if !found {
+			return diag.Errorf("secret %q no longer exists, so its value cannot be preserved", oldKey.(string))
+		}
+	}
+
+	// Keep tags added outside Terraform, removing only tags that were dropped
+	// from the configuration
+	if d.Get("tags_mode").(string) == TagsModeMerge {
+		oldKey, _ := d.GetChange("key")
+		existing, err := client.ReadSecret(ctx, appID, env, oldKey.(string))
+		if err != nil && !isNotFound(err) {
+			return diag.FromErr(err)
+		}
+		for _, current := range existing {
+			if current.ID == secret.ID {
+				oldTags, _ := d.GetChange("tags")
+				secret.Tags = mergeTags(current.Tags, expandTags(oldTags.(*schema.Set)), secret.Tags)
+				break
+			}
+		}
+	}
+
+	// Mark protected secrets in Phase, so that bulk deletion skips them
+	secret.Tags = protectionTags(secret.Tags, d.Get("prevent_destroy_on_server").(bool))
+
+	// A rename updates the existing secret in place by ID, which must not
+	// produce two secrets with the same key at the path
+	if d.HasChange("key") || d.HasChange("path") {
+		existingID, err := currentSecretID(ctx, client, appID, env, secret.Key, secret.Path)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if existingID != "" && existingID != secret.ID {
+			return diag.Errorf("cannot rename secret to %q: a secret with that key already exists at path %q", secret.Key, secret.Path)
+		}
+
+		oldKey, _ := d.GetChange("key")
+		log.Printf("[DEBUG] Renaming secret %s to %s at path %s", oldKey.(string), secret.Key, secret.Path)
+	}
+
+	_, err = client.UpdateSecret(ctx, appID, env, secret)
+	if isNotFound(err) {
+		// The secret may have been deleted and recreated outside Terraform,
+		// leaving a stale ID in state, so look it up by key and retry once
+		oldKey, _ := d.GetChange("key")
+		oldPath, _ := d.GetChange("path")
+
+		id, lookupErr := currentSecretID(ctx, client, appID, env, oldKey.(string), normalizePath(oldPath.(string)))
+		if lookupErr != nil {
+			return diag.FromErr(lookupErr)
+		}
+		if id != "" && id != secret.ID {
+			log.Printf("[WARN] Secret %s was recreated outside Terraform, updating it with its new ID %s", oldKey.(string), id)
+			secret.ID = id
+			d.SetId(id)
+			_, err = client.UpdateSecret(ctx, appID, env, secret)
+		}
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -248,6 +1673,35 @@ func resourceSecretUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 	return resourceSecretRead(ctx, d, meta)
 }
 
+// allowProtectedDestroy reports whether deleting secrets with
+// prevent_destroy_on_server set has been explicitly allowed
+func allowProtectedDestroy() bool {
+	allow, _ := strconv.ParseBool(os.Getenv(AllowProtectedDestroyEnv))
+	return allow
+}
+
+// currentSecretID returns the ID of the secret with key at path, or an empty
+// string if it does not exist
+func currentSecretID(ctx context.Context, client *PhaseClient, appID, env, key, path string) (string, error) {
+	secrets, err := client.ReadSecret(ctx, appID, env, key)
+	if err != nil && !isNotFound(err) {
+		return "", err
+	}
+
+	return currentSecretIDIn(secrets, key, path), nil
+}
+
+// currentSecretIDIn returns the ID of the secret with key at path among
+// secrets, or an empty string if it is not one of them
+func currentSecretIDIn(secrets []Secret, key, path string) string {
+	for _, secret := range secrets {
+		if secret.Key == key && secret.Path == path {
+			return secret.ID
+		}
+	}
+	return ""
+}
+
 func resourceSecretDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*PhaseClient)
 
@@ -255,7 +1709,40 @@ func resourceSecretDelete(ctx context.Context, d *schema.ResourceData, meta inte
 	env := d.Get("env").(string)
 	secretID := d.Id()
 
-	err := client.DeleteSecret(appID, env, secretID, fmt.Sprintf("Bearer %s", client.TokenType))
+	if d.Get("prevent_destroy_on_server").(bool) && !allowProtectedDestroy() {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Secret %s is protected from deletion", d.Get("key").(string)),
+			Detail:   fmt.Sprintf("prevent_destroy_on_server is set on this secret, so it was not deleted. To delete it, set prevent_destroy_on_server = false and apply first, or set the %s environment variable to true for this run.", AllowProtectedDestroyEnv),
+		}}
+	}
+
+	if d.Get("verify_before_delete").(bool) {
+		key := d.Get("key").(string)
+		path := normalizePath(d.Get("path").(string))
+
+		// Reading by key finds the secret without listing the whole
+		// environment. Only if its ID no longer has that key is the whole
+		// environment listed, to tell a renamed secret from a deleted one.
+		current, err := findSecretByID(ctx, client, appID, env, key, secretID)
+		if err == nil && current == nil {
+			current, err = findSecretByID(ctx, client, appID, env, "", secretID)
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if current == nil {
+			log.Printf("[WARN] Secret %s (%s) no longer exists, removing from state", key, secretID)
+			d.SetId("")
+			return nil
+		}
+		if current.Key != key || current.Path != path {
+			return diag.Errorf("refusing to delete secret %s: expected key %q at path %q but found key %q at path %q, the state may be stale", secretID, key, path, current.Key, current.Path)
+		}
+	}
+
+	err := client.DeleteSecret(ctx, appID, env, secretID)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -264,14 +1751,37 @@ func resourceSecretDelete(ctx context.Context, d *schema.ResourceData, meta inte
 	return nil
 }
 
+// findSecretByID returns the secret with secretID among those with key, or
+// among every secret in env if key is empty. It returns nil if there is none.
+func findSecretByID(ctx context.Context, client *PhaseClient, appID, env, key, secretID string) (*Secret, error) {
+	secrets, err := client.ReadSecret(ctx, appID, env, key)
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+
+	for i := range secrets {
+		if secrets[i].ID == secretID {
+			return &secrets[i], nil
+		}
+	}
+	return nil, nil
+}
+
 func dataSourceSecrets() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceSecretsRead,
 		Schema: map[string]*schema.Schema{
 			"app_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"app", "app_id"},
+				Description:  "The ID of the Phase App.",
+			},
+			"app": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The ID of the Phase App.",
+				Optional:    true,
+				Description: "The name of the Phase App, resolved to its ID. Use instead of app_id to keep configurations portable between Phase instances.",
 			},
 			"env": {
 				Type:        schema.TypeString,
@@ -285,9 +1795,94 @@ func dataSourceSecrets() *schema.Resource {
 				Description: "The path to fetch secrets from.",
 			},
 			"key": {
-				Type:        schema.TypeString,
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"keys"},
+				Description:   "The key of a specific secret to fetch.",
+			},
+			"keys": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"key"},
+				Description:   "The keys of the secrets to fetch. Keys that do not exist are omitted.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"recursive": {
+				Type:        schema.TypeBool,
 				Optional:    true,
-				Description: "The key of a specific secret to fetch.",
+				Default:     false,
+				Description: "Include secrets from all paths nested under path.",
+			},
+			"flatten_keys": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When recursive is set, prefix keys of nested secrets with their path relative to path, e.g. \"db/URL\".",
+			},
+			"resolve_references": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Expand ${KEY} references to other secrets at the same path in returned values.",
+			},
+			"tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Only return secrets with these tags.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"tag_match": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          TagMatchAny,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{TagMatchAny, TagMatchAll}, false)),
+				Description:      "Whether secrets must have any or all of tags: any or all.",
+			},
+			"only_active": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Exclude disabled secrets from the result.",
+			},
+			"exclude_expired": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Exclude secrets whose expiry time has passed from the result.",
+			},
+			"as_of": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+				Description:      "Return secrets as they were at this time, in RFC 3339 format such as 2025-01-31T00:00:00Z.",
+			},
+			"decode_base64": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"decode_base64_keys"},
+				Description:   "Base64-decode every returned value. Reading fails if any value is not valid base64 or does not decode to UTF-8 text.",
+			},
+			"decode_base64_keys": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"decode_base64"},
+				Description:   "Base64-decode only the values of these keys. Reading fails if one of them does not decode cleanly.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"decode_json_keys": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Keys whose JSON values are decoded into secrets_json.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
 			},
 			"secrets": {
 				Type:      schema.TypeMap,
@@ -297,6 +1892,84 @@ func dataSourceSecrets() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"secrets_json": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The decoded fields of the secrets listed in decode_json_keys, keyed by the secret key and the dotted path to each field, e.g. \"SA_KEY.client_email\".",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"infer_types": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Encode values that are JSON numbers or booleans as numbers and booleans in secrets_object instead of as strings.",
+			},
+			"secrets_object": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The secrets in the secrets map encoded as a JSON object. Decode it with jsondecode to use the secrets as an object.",
+			},
+			"secrets_metadata": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Metadata for each secret returned, sorted by key. Use secrets_metadata_object to look up metadata by key.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"comment": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"updated_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"inherited": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"inherited_from": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"expires_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"secrets_metadata_object": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The metadata of each secret in the secrets map encoded as a JSON object keyed the same way as secrets. Decode it with jsondecode to look up metadata by key.",
+			},
 		},
 	}
 }
@@ -304,27 +1977,144 @@ func dataSourceSecrets() *schema.Resource {
 func dataSourceSecretsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*PhaseClient)
 
+	if name := d.Get("app").(string); name != "" {
+		app, err := findAppByName(ctx, client, name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("app_id", app.ID)
+	}
+
 	appID := d.Get("app_id").(string)
 	env := d.Get("env").(string)
-	path := d.Get("path").(string)
+	path := normalizePath(d.Get("path").(string))
 	key := d.Get("key").(string)
+	recursive := d.Get("recursive").(bool)
+	flattenKeys := d.Get("flatten_keys").(bool)
+	resolveReferences := d.Get("resolve_references").(bool)
+	matchAllTags := d.Get("tag_match").(string) == TagMatchAll
+	onlyActive := d.Get("only_active").(bool)
+	excludeExpired := d.Get("exclude_expired").(bool)
+	now := time.Now()
+
+	// Expiry is also evaluated at the requested time
+	var asOf time.Time
+	if raw := d.Get("as_of").(string); raw != "" {
+		var err error
+		asOf, err = time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return diag.Errorf("invalid as_of %q: %s", raw, err)
+		}
+		if asOf.After(now) {
+			return diag.Errorf("as_of %s is in the future", raw)
+		}
+		now = asOf
+	}
+
+	var tags []string
+	for _, tag := range d.Get("tags").([]interface{}) {
+		tags = append(tags, tag.(string))
+	}
+
+	decodeAllBase64 := d.Get("decode_base64").(bool)
+	decodeBase64Keys := make(map[string]bool)
+	for _, k := range d.Get("decode_base64_keys").([]interface{}) {
+		decodeBase64Keys[k.(string)] = true
+	}
 
 	// Determine if we're fetching all secrets
 	fetchingAll := path == ""
 
-	secrets, err := client.ReadSecret(appID, env, key, fmt.Sprintf("Bearer %s", client.TokenType))
-	if err != nil {
+	keys := make(map[string]bool)
+	for _, k := range d.Get("keys").([]interface{}) {
+		keys[k.(string)] = true
+	}
+
+	// Several keys are filtered from a single list of every secret, and
+	// references may point at any key, so fetch everything in those cases
+	fetchKey := key
+	if len(keys) == 1 {
+		for k := range keys {
+			fetchKey = k
+		}
+	}
+	if resolveReferences || len(keys) > 1 {
+		fetchKey = ""
+	}
+
+	// Let the server filter by path when a single path is read in full
+	var secrets []Secret
+	var err error
+	if !asOf.IsZero() {
+		secrets, err = client.ReadSecretAsOf(ctx, appID, env, fetchKey, asOf)
+	} else if fetchKey == "" && !fetchingAll && !recursive {
+		secrets, err = client.ListSecrets(ctx, appID, env, path)
+	} else {
+		secrets, err = client.ReadSecret(ctx, appID, env, fetchKey)
+	}
+	if err != nil && !isNotFound(err) {
 		return diag.FromErr(err)
 	}
 
+	var resolver *referenceResolver
+	if resolveReferences {
+		resolver = newReferenceResolver(secrets)
+	}
+
 	secretMap := make(map[string]string)
+	metadataMap := make(map[string]Secret)
+	var matched []Secret
 	for _, secret := range secrets {
-		if fetchingAll || secret.Path == path {
-			if secret.Override != nil && secret.Override.IsActive {
-				secretMap[secret.Key] = secret.Override.Value
-			} else {
-				secretMap[secret.Key] = secret.Value
+		if key != "" && secret.Key != key {
+			continue
+		}
+
+		if len(keys) > 0 && !keys[secret.Key] {
+			continue
+		}
+
+		if !secretHasTags(secret, tags, matchAllTags) {
+			continue
+		}
+
+		if onlyActive && secret.Disabled {
+			continue
+		}
+
+		if excludeExpired && secretExpired(secret, now) {
+			continue
+		}
+
+		if fetchingAll || secretInPath(secret.Path, path, recursive) {
+			mapKey := secret.Key
+			if recursive && flattenKeys {
+				mapKey = relativeSecretKey(secret, path)
 			}
+
+			value := secretValue(secret)
+			if resolver != nil {
+				value, err = resolver.Resolve(secret)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+			}
+
+			if decodeAllBase64 || decodeBase64Keys[mapKey] {
+				value, err = decodeBase64Value(mapKey, value)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+			}
+
+			secretMap[mapKey] = value
+			metadataMap[mapKey] = secret
+			matched = append(matched, secret)
+		}
+	}
+
+	if key != "" && !asOf.IsZero() && len(matched) == 0 {
+		if err := checkSecretExistedAt(ctx, client, appID, env, key, path, recursive, asOf); err != nil {
+			return diag.FromErr(err)
 		}
 	}
 
@@ -332,13 +2122,239 @@ func dataSourceSecretsRead(ctx context.Context, d *schema.ResourceData, meta int
 		return diag.FromErr(err)
 	}
 
+	secretsJSON := make(map[string]string)
+	for _, raw := range d.Get("decode_json_keys").([]interface{}) {
+		if value, ok := secretMap[raw.(string)]; ok {
+			flattenSecretJSON(raw.(string), value, secretsJSON)
+		}
+	}
+	if err := d.Set("secrets_json", secretsJSON); err != nil {
+		return diag.FromErr(err)
+	}
+
+	secretsObject, err := encodeSecretsObject(secretMap, d.Get("infer_types").(bool))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("secrets_object", secretsObject); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("secrets_metadata", flattenSecretsMetadata(matched)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	metadataObject, err := encodeSecretsMetadataObject(metadataMap)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("secrets_metadata_object", metadataObject); err != nil {
+		return diag.FromErr(err)
+	}
+
 	// Set the path in the state
 	if err := d.Set("path", path); err != nil {
 		return diag.FromErr(err)
 	}
 
-	// Generate a unique ID for the data source
-	d.SetId(fmt.Sprintf("%s-%s-%s-%s", appID, env, path, key))
+	d.SetId(dataSourceSecretsID(d, path))
 
 	return nil
 }
+
+// dataSourceSecretsID returns a stable ID derived from the normalized inputs of
+// the phase_secrets data source. Lists whose order does not affect the result
+// are sorted so that reordering them does not change the ID.
+func dataSourceSecretsID(d *schema.ResourceData, path string) string {
+	sortedList := func(attr string) []string {
+		values := make([]string, 0)
+		for _, v := range d.Get(attr).([]interface{}) {
+			values = append(values, v.(string))
+		}
+		sort.Strings(values)
+		return values
+	}
+
+	inputs, _ := json.Marshal([]interface{}{
+		d.Get("app_id").(string),
+		d.Get("env").(string),
+		path,
+		d.Get("key").(string),
+		sortedList("keys"),
+		sortedList("tags"),
+		d.Get("tag_match").(string),
+		d.Get("only_active").(bool),
+		d.Get("exclude_expired").(bool),
+		d.Get("recursive").(bool),
+		d.Get("flatten_keys").(bool),
+		d.Get("resolve_references").(bool),
+		sortedList("decode_json_keys"),
+		d.Get("infer_types").(bool),
+		d.Get("decode_base64").(bool),
+		sortedList("decode_base64_keys"),
+		d.Get("as_of").(string),
+	})
+
+	sum := sha256.Sum256(inputs)
+	return hex.EncodeToString(sum[:])
+}
+
+// checkSecretExistedAt returns an error if the secret with key under path was
+// created after asOf, so a historical read does not silently return nothing
+func checkSecretExistedAt(ctx context.Context, client *PhaseClient, appID, env, key, path string, recursive bool, asOf time.Time) error {
+	secrets, err := client.ReadSecret(ctx, appID, env, key)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+
+	for _, secret := range secrets {
+		if secret.Key != key || (path != "" && !secretInPath(secret.Path, path, recursive)) {
+			continue
+		}
+		createdAt, err := time.Parse(time.RFC3339, secret.CreatedAt)
+		if err == nil && createdAt.After(asOf) {
+			return fmt.Errorf("secret %q did not exist at %s: it was created at %s", key, asOf.Format(time.RFC3339), secret.CreatedAt)
+		}
+	}
+
+	return nil
+}
+
+// secretExpired reports whether a secret's expiry time is before now. Secrets
+// without an expiry, or with one that cannot be parsed, never expire.
+func secretExpired(secret Secret, now time.Time) bool {
+	if secret.ExpiresAt == "" {
+		return false
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, secret.ExpiresAt)
+	if err != nil {
+		return false
+	}
+
+	return expiresAt.Before(now)
+}
+
+// suppressEquivalentTime suppresses diffs between RFC 3339 timestamps that
+// refer to the same instant, such as the same time in different time zones
+func suppressEquivalentTime(k, old, new string, d *schema.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return oldTime.Equal(newTime)
+}
+
+// secretHasTags reports whether a secret has any of the given tags, or all of
+// them when matchAll is set. Every secret matches an empty list of tags.
+func secretHasTags(secret Secret, tags []string, matchAll bool) bool {
+	if len(tags) == 0 {
+		return true
+	}
+
+	for _, tag := range tags {
+		found := slices.Contains(secret.Tags, tag)
+		if found && !matchAll {
+			return true
+		}
+		if !found && matchAll {
+			return false
+		}
+	}
+
+	return matchAll
+}
+
+// secretInPath reports whether a secret at secretPath belongs to path, including
+// nested paths when recursive is set
+func secretInPath(secretPath, path string, recursive bool) bool {
+	if secretPath == path {
+		return true
+	}
+	if !recursive {
+		return false
+	}
+
+	prefix := strings.TrimSuffix(path, "/") + "/"
+	return strings.HasPrefix(secretPath, prefix)
+}
+
+// relativeSecretKey prefixes a secret's key with its path relative to path
+func relativeSecretKey(secret Secret, path string) string {
+	relative := strings.Trim(strings.TrimPrefix(secret.Path, strings.TrimSuffix(path, "/")), "/")
+	if relative == "" {
+		return secret.Key
+	}
+	return relative + "/" + secret.Key
+}
+
+// flattenSecretsMetadata converts secrets into secrets_metadata entries sorted by key and path
+func flattenSecretsMetadata(secrets []Secret) []interface{} {
+	sorted := make([]Secret, len(secrets))
+	copy(sorted, secrets)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Key != sorted[j].Key {
+			return sorted[i].Key < sorted[j].Key
+		}
+		return sorted[i].Path < sorted[j].Path
+	})
+
+	metadata := make([]interface{}, 0, len(sorted))
+	for _, secret := range sorted {
+		metadata = append(metadata, secretMetadata(secret))
+	}
+
+	return metadata
+}
+
+// secretMetadata describes a secret without its value
+func secretMetadata(secret Secret) map[string]interface{} {
+	tags := secret.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return map[string]interface{}{
+		"key":            secret.Key,
+		"version":        secret.Version,
+		"comment":        secret.Comment,
+		"path":           secret.Path,
+		"tags":           tags,
+		"created_at":     secret.CreatedAt,
+		"updated_at":     secret.UpdatedAt,
+		"inherited":      secret.Inherited,
+		"inherited_from": secret.InheritedFrom,
+		"expires_at":     secret.ExpiresAt,
+	}
+}
+
+// validateSecretKey checks that a secret key follows Phase's naming rules
+func validateSecretKey(v interface{}, path cty.Path) diag.Diagnostics {
+	key := v.(string)
+	if SecretKeyPattern.MatchString(key) {
+		return nil
+	}
+
+	var reason string
+	switch {
+	case key == "":
+		reason = "Keys must not be empty."
+	case strings.ContainsAny(key, " \t\n"):
+		reason = "Keys must not contain whitespace."
+	case key[0] >= '0' && key[0] <= '9':
+		reason = "Keys must not start with a digit."
+	default:
+		reason = "Keys may only contain letters, digits and underscores, for example DATABASE_URL."
+	}
+
+	return diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       fmt.Sprintf("Invalid secret key %q", key),
+		Detail:        reason,
+		AttributePath: path,
+	}}
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket that allows bursts of up to burst requests and
+// refills at rate tokens per second
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter creates a full token bucket allowing the given number of
+// requests per second
+func newRateLimiter(requestsPerSecond int) *rateLimiter {
+	return &rateLimiter{
+		rate:   float64(requestsPerSecond),
+		burst:  float64(requestsPerSecond),
+		tokens: float64(requestsPerSecond),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or the context is done. Tokens are
+// reserved up front so concurrent callers are served in arrival order.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		// Hand back the reservation so other callers are not delayed by it
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// rateLimitTransport wraps an http.RoundTripper and waits on a rate limiter
+// before sending each request
+type rateLimitTransport struct {
+	base    http.RoundTripper
+	limiter *rateLimiter
+}
+
+// RoundTrip waits for a token and then executes the request
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	l := newRateLimiter(20)
+
+	start := time.Now()
+	for i := 0; i < 20; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 25*time.Millisecond {
+		t.Errorf("a full burst of 20 took %s, want no waiting", elapsed)
+	}
+
+	// The bucket is empty, so the next request waits for one token
+	start = time.Now()
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("request after the burst waited %s, want about 50ms", elapsed)
+	}
+}
+
+func TestRateLimiterConcurrent(t *testing.T) {
+	l := newRateLimiter(50)
+
+	// 50 requests are served from the burst and the remaining 25 are spread
+	// over half a second at 50 per second
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < 75; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := l.Wait(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	elapsed := time.Since(start)
+	if elapsed < 450*time.Millisecond || elapsed > 2*time.Second {
+		t.Errorf("75 concurrent requests at 50 per second took %s, want about 500ms", elapsed)
+	}
+}
+
+func TestRateLimiterCancel(t *testing.T) {
+	l := newRateLimiter(1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait with an expiring context = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// The cancelled reservation is handed back, so the next caller waits
+	// for one token rather than two
+	start := time.Now()
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 1500*time.Millisecond {
+		t.Errorf("request after a cancelled wait took %s, want at most one token interval", elapsed)
+	}
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	var served int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&served, 1)
+	}))
+	defer server.Close()
+
+	limiter := newRateLimiter(1)
+	client := &http.Client{Transport: &rateLimitTransport{base: http.DefaultTransport, limiter: limiter}}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	// The bucket is empty, so a request whose context ends first is never sent
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if _, err := client.Do(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("request with an expiring context = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if n := atomic.LoadInt32(&served); n != 1 {
+		t.Errorf("server received %d requests, want 1", n)
+	}
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// redactedPlaceholder replaces sensitive content in error messages
+const redactedPlaceholder = "[REDACTED]"
+
+// sensitiveFields are JSON field names whose values are always redacted
+var sensitiveFields = map[string]bool{
+	"value":    true,
+	"token":    true,
+	"secret":   true,
+	"password": true,
+}
+
+// redactBody scrubs sensitive content from a response body before it is
+// included in an error. Values of sensitive JSON fields are replaced, and any
+// occurrence of the given known sensitive values is replaced as well.
+func redactBody(body []byte, sensitiveValues ...string) string {
+	redacted := string(body)
+
+	var parsed interface{}
+	if err := json.Unmarshal(body, &parsed); err == nil {
+		if data, err := json.Marshal(redactJSON(parsed)); err == nil {
+			redacted = string(data)
+		}
+	}
+
+	for _, value := range sensitiveValues {
+		if value != "" {
+			redacted = strings.ReplaceAll(redacted, value, redactedPlaceholder)
+		}
+	}
+
+	return redacted
+}
+
+// redactJSON replaces the values of sensitive fields in decoded JSON
+func redactJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for field, fieldValue := range v {
+			if sensitiveFields[strings.ToLower(field)] {
+				v[field] = redactedPlaceholder
+			} else {
+				v[field] = redactJSON(fieldValue)
+			}
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = redactJSON(v[i])
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// secretValues returns the values and override values of secrets so they can
+// be redacted from errors
+func secretValues(secrets []Secret) []string {
+	var values []string
+	for _, secret := range secrets {
+		values = append(values, secret.Value)
+		if secret.Override != nil {
+			values = append(values, secret.Override.Value)
+		}
+		for _, override := range secret.Overrides {
+			values = append(values, override.Value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// secretReferencePattern matches ${KEY} references inside secret values
+var secretReferencePattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// secretRef identifies a secret by its path and key
+type secretRef struct {
+	path string
+	key  string
+}
+
+// referenceResolver expands ${KEY} references in secret values using the other
+// secrets at the same path
+type referenceResolver struct {
+	raw      map[secretRef]string
+	resolved map[secretRef]string
+	visiting map[secretRef]bool
+}
+
+// newReferenceResolver creates a resolver over the given secrets
+func newReferenceResolver(secrets []Secret) *referenceResolver {
+	raw := make(map[secretRef]string, len(secrets))
+	for _, secret := range secrets {
+		raw[secretRef{path: secret.Path, key: secret.Key}] = secretValue(secret)
+	}
+
+	return &referenceResolver{
+		raw:      raw,
+		resolved: make(map[secretRef]string),
+		visiting: make(map[secretRef]bool),
+	}
+}
+
+// Resolve returns the value of the secret with all references expanded.
+// References to keys that do not exist at the same path are left untouched.
+func (r *referenceResolver) Resolve(secret Secret) (string, error) {
+	return r.resolve(secretRef{path: secret.Path, key: secret.Key}, nil)
+}
+
+func (r *referenceResolver) resolve(ref secretRef, chain []string) (string, error) {
+	if value, ok := r.resolved[ref]; ok {
+		return value, nil
+	}
+
+	chain = append(chain, ref.key)
+	if r.visiting[ref] {
+		return "", fmt.Errorf("secret reference cycle detected at path %s: %s", ref.path, strings.Join(chain, " -> "))
+	}
+
+	r.visiting[ref] = true
+	defer delete(r.visiting, ref)
+
+	var resolveErr error
+	value := secretReferencePattern.ReplaceAllStringFunc(r.raw[ref], func(match string) string {
+		if resolveErr != nil {
+			return match
+		}
+
+		target := secretRef{path: ref.path, key: secretReferencePattern.FindStringSubmatch(match)[1]}
+		if _, ok := r.raw[target]; !ok {
+			return match
+		}
+
+		targetValue, err := r.resolve(target, chain)
+		if err != nil {
+			resolveErr = err
+			return match
+		}
+		return targetValue
+	})
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+
+	r.resolved[ref] = value
+	return value, nil
+}
+
+// secretValue returns the effective value of a secret, preferring an active
+// Personal Secret Override
+func secretValue(secret Secret) string {
+	if secret.Override != nil && secret.Override.IsActive {
+		return secret.Override.Value
+	}
+	return secret.Value
+}
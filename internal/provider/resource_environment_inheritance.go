@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceEnvironmentInheritance() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceEnvironmentInheritanceCreate,
+		ReadContext:   resourceEnvironmentInheritanceRead,
+		UpdateContext: resourceEnvironmentInheritanceUpdate,
+		DeleteContext: resourceEnvironmentInheritanceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceEnvironmentInheritanceImport,
+		},
+		CustomizeDiff: validateEnvironmentInheritance,
+
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the Phase App.",
+			},
+			"env": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the environment that inherits secrets.",
+			},
+			"inherits_from": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+				Description:      "The name of the environment to inherit secrets from.",
+			},
+		},
+	}
+}
+
+// validateEnvironmentInheritance rejects an environment inheriting from itself
+func validateEnvironmentInheritance(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	env := d.Get("env").(string)
+	if d.NewValueKnown("env") && d.NewValueKnown("inherits_from") && d.Get("inherits_from").(string) == env {
+		return fmt.Errorf("environment %q cannot inherit from itself", env)
+	}
+	return nil
+}
+
+func resourceEnvironmentInheritanceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	appID := d.Get("app_id").(string)
+	env := d.Get("env").(string)
+
+	err := client.SetEnvironmentInheritance(ctx, appID, env, d.Get("inherits_from").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", appID, env))
+
+	return resourceEnvironmentInheritanceRead(ctx, d, meta)
+}
+
+func resourceEnvironmentInheritanceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	appID := d.Get("app_id").(string)
+	env := d.Get("env").(string)
+
+	inheritance, err := client.GetEnvironmentInheritance(ctx, appID, env)
+	if isNotFound(err) {
+		log.Printf("[WARN] Environment %s not found in app %s, removing from state", env, appID)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Inheritance changed or removed in the Phase console shows up as drift
+	d.Set("inherits_from", inheritance.InheritsFrom)
+
+	return nil
+}
+
+func resourceEnvironmentInheritanceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	appID := d.Get("app_id").(string)
+	env := d.Get("env").(string)
+
+	err := client.SetEnvironmentInheritance(ctx, appID, env, d.Get("inherits_from").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceEnvironmentInheritanceRead(ctx, d, meta)
+}
+
+func resourceEnvironmentInheritanceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	appID := d.Get("app_id").(string)
+	env := d.Get("env").(string)
+
+	// Only the inheritance is removed, the environment itself is kept
+	err := client.SetEnvironmentInheritance(ctx, appID, env, "")
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceEnvironmentInheritanceImport imports the inheritance of an
+// environment using an ID of the form app_id/env
+func resourceEnvironmentInheritanceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	appID, env, ok := strings.Cut(d.Id(), "/")
+	if !ok || appID == "" || env == "" {
+		return nil, fmt.Errorf("invalid import ID %q, expected app_id/env", d.Id())
+	}
+
+	d.Set("app_id", appID)
+	d.Set("env", env)
+
+	importID := d.Id()
+
+	diags := resourceEnvironmentInheritanceRead(ctx, d, meta)
+	if diags.HasError() {
+		return nil, fmt.Errorf("failed to import environment inheritance %q: %s", importID, diags[0].Summary)
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("failed to import environment inheritance %q: environment not found", importID)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceLockbox() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceLockboxCreate,
+		ReadContext:   resourceLockboxRead,
+		DeleteContext: resourceLockboxDelete,
+
+		Schema: map[string]*schema.Schema{
+			"value": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Sensitive:   true,
+				Description: "The value to share.",
+			},
+			"ttl": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          DefaultLockboxTTL,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(60)),
+				Description:      "The time in seconds after which the share expires.",
+			},
+			"max_views": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          1,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				Description:      "The number of times the share can be opened.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The URL of the share. It is only available when the share is created.",
+			},
+			"views": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of times the share has been opened.",
+			},
+			"expires_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the share expires.",
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the share can still be opened. It becomes false once the share expires, reaches max_views or is revoked.",
+			},
+		},
+	}
+}
+
+func resourceLockboxCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	lockbox, err := client.CreateLockbox(ctx, Lockbox{
+		Data:         d.Get("value").(string),
+		TTL:          d.Get("ttl").(int),
+		AllowedViews: d.Get("max_views").(int),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(lockbox.ID)
+	d.Set("url", lockbox.URL)
+
+	return resourceLockboxRead(ctx, d, meta)
+}
+
+func resourceLockboxRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	// A used or expired share is kept in state, so that it is not replaced by
+	// a new share on every apply
+	lockbox, err := client.GetLockbox(ctx, d.Id())
+	if isNotFound(err) {
+		log.Printf("[DEBUG] Lockbox %s is no longer available", d.Id())
+		d.Set("active", false)
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The URL is only returned on create, so it is kept from state
+	d.Set("views", lockbox.Views)
+	d.Set("expires_at", lockbox.ExpiresAt)
+	d.Set("active", lockboxActive(lockbox, time.Now()))
+
+	return nil
+}
+
+// lockboxActive reports whether a share can still be opened at now. A share
+// whose expiry time cannot be parsed is only limited by its views.
+func lockboxActive(lockbox *Lockbox, now time.Time) bool {
+	if lockbox.AllowedViews > 0 && lockbox.Views >= lockbox.AllowedViews {
+		return false
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, lockbox.ExpiresAt)
+	return err != nil || now.Before(expiresAt)
+}
+
+func resourceLockboxDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	err := client.DeleteLockbox(ctx, d.Id())
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLockboxActive(t *testing.T) {
+	now := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		lockbox Lockbox
+		want    bool
+	}{
+		{name: "unopened", lockbox: Lockbox{AllowedViews: 1, ExpiresAt: "2030-01-02T00:00:00Z"}, want: true},
+		{name: "views left", lockbox: Lockbox{AllowedViews: 3, Views: 2, ExpiresAt: "2030-01-02T00:00:00Z"}, want: true},
+		{name: "views used", lockbox: Lockbox{AllowedViews: 3, Views: 3, ExpiresAt: "2030-01-02T00:00:00Z"}, want: false},
+		{name: "expired", lockbox: Lockbox{AllowedViews: 1, ExpiresAt: "2030-01-01T11:59:59Z"}, want: false},
+		{name: "expired in another zone", lockbox: Lockbox{AllowedViews: 1, ExpiresAt: "2030-01-01T12:30:00+01:00"}, want: false},
+		{name: "fractional seconds", lockbox: Lockbox{AllowedViews: 1, ExpiresAt: "2030-01-01T12:00:00.5Z"}, want: true},
+		{name: "no expiry", lockbox: Lockbox{AllowedViews: 1}, want: true},
+		{name: "unlimited views", lockbox: Lockbox{Views: 10, ExpiresAt: "2030-01-02T00:00:00Z"}, want: true},
+	}
+
+	for _, tt := range tests {
+		if got := lockboxActive(&tt.lockbox, now); got != tt.want {
+			t.Errorf("%s: lockboxActive() = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCreateLockboxOmitsViews(t *testing.T) {
+	var sent map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		writeJSON(w, Lockbox{ID: "lockbox", URL: "https://example.com/lockbox"})
+	}))
+	defer server.Close()
+
+	client := &PhaseClient{
+		HostURL:    server.URL,
+		APIVersion: DefaultAPIVersion,
+		HTTPClient: server.Client(),
+		Token:      "token",
+		TokenType:  "ServiceAccount",
+	}
+
+	if _, err := client.CreateLockbox(context.Background(), Lockbox{Data: "value", TTL: 3600, AllowedViews: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := sent["views"]; ok {
+		t.Errorf("create request sent views: %v", sent)
+	}
+	if sent["allowedViews"] != float64(1) || sent["ttl"] != float64(3600) {
+		t.Errorf("create request = %v, want allowedViews 1 and ttl 3600", sent)
+	}
+}
@@ -0,0 +1,279 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceSecretMultiEnv() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSecretMultiEnvCreate,
+		ReadContext:   resourceSecretMultiEnvRead,
+		UpdateContext: resourceSecretMultiEnvUpdate,
+		DeleteContext: resourceSecretMultiEnvDelete,
+
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the Phase App.",
+			},
+			"key": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateSecretKey,
+				Description:      "The secret key.",
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "/",
+				ForceNew:    true,
+				StateFunc:   normalizePathStateFunc,
+				Description: "The path the secret is stored under in every environment.",
+			},
+			"environment": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "The environments to manage the secret in. Each environment may only appear once.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The environment name.",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "The secret value in this environment.",
+						},
+						"comment": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "A comment describing the secret in this environment.",
+						},
+					},
+				},
+			},
+			"secret_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "A map of environment names to the secret's Phase ID in that environment.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceSecretMultiEnvCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	appID := d.Get("app_id").(string)
+	key := d.Get("key").(string)
+	path := normalizePath(d.Get("path").(string))
+
+	environments, err := expandSecretEnvironments(d.Get("environment").(*schema.Set))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s%s/%s", appID, strings.TrimSuffix(path, "/"), key))
+
+	// Record each secret as it is created so a failure part way through does
+	// not orphan the secrets already created in other environments
+	secretIDs := make(map[string]interface{})
+	for env, entry := range environments {
+		created, err := client.CreateSecret(ctx, appID, env, Secret{
+			Key:     key,
+			Value:   entry.Value,
+			Comment: entry.Comment,
+			Path:    path,
+		})
+		if err != nil {
+			d.Set("secret_ids", secretIDs)
+			return diag.Errorf("error creating secret in environment %q: %s", env, err)
+		}
+		secretIDs[env] = created.ID
+	}
+
+	if err := d.Set("secret_ids", secretIDs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceSecretMultiEnvRead(ctx, d, meta)
+}
+
+func resourceSecretMultiEnvRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	appID := d.Get("app_id").(string)
+	key := d.Get("key").(string)
+	path := normalizePath(d.Get("path").(string))
+
+	// Reconcile each managed environment separately, dropping any where the
+	// secret no longer exists so it is recreated on the next apply
+	var entries []interface{}
+	secretIDs := make(map[string]interface{})
+	for env := range d.Get("secret_ids").(map[string]interface{}) {
+		secrets, err := client.ReadSecret(ctx, appID, env, key)
+		if err != nil && !isNotFound(err) {
+			return diag.Errorf("error reading secret in environment %q: %s", env, err)
+		}
+
+		var found *Secret
+		for i := range secrets {
+			if secrets[i].Key == key && secrets[i].Path == path {
+				found = &secrets[i]
+				break
+			}
+		}
+		if found == nil {
+			log.Printf("[WARN] Secret %s not found in environment %q, removing it from state", key, env)
+			continue
+		}
+
+		entries = append(entries, map[string]interface{}{
+			"name":    env,
+			"value":   found.Value,
+			"comment": found.Comment,
+		})
+		secretIDs[env] = found.ID
+	}
+
+	if len(secretIDs) == 0 {
+		log.Printf("[WARN] Secret %s not found in any environment, removing from state", key)
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("environment", entries); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("secret_ids", secretIDs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceSecretMultiEnvUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	appID := d.Get("app_id").(string)
+	key := d.Get("key").(string)
+	path := normalizePath(d.Get("path").(string))
+
+	environments, err := expandSecretEnvironments(d.Get("environment").(*schema.Set))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	oldRaw, _ := d.GetChange("environment")
+	previous, err := expandSecretEnvironments(oldRaw.(*schema.Set))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	secretIDs := d.Get("secret_ids").(map[string]interface{})
+
+	for env, id := range secretIDs {
+		if _, ok := environments[env]; ok {
+			continue
+		}
+		if err := client.DeleteSecret(ctx, appID, env, id.(string)); err != nil {
+			d.Set("secret_ids", secretIDs)
+			return diag.Errorf("error deleting secret in environment %q: %s", env, err)
+		}
+		delete(secretIDs, env)
+	}
+
+	for env, entry := range environments {
+		secret := Secret{
+			Key:     key,
+			Value:   entry.Value,
+			Comment: entry.Comment,
+			Path:    path,
+		}
+
+		id, ok := secretIDs[env]
+		if !ok {
+			created, err := client.CreateSecret(ctx, appID, env, secret)
+			if err != nil {
+				d.Set("secret_ids", secretIDs)
+				return diag.Errorf("error creating secret in environment %q: %s", env, err)
+			}
+			secretIDs[env] = created.ID
+			continue
+		}
+
+		if old, ok := previous[env]; ok && old == entry {
+			continue
+		}
+
+		secret.ID = id.(string)
+		if _, err := client.UpdateSecret(ctx, appID, env, secret); err != nil {
+			d.Set("secret_ids", secretIDs)
+			return diag.Errorf("error updating secret in environment %q: %s", env, err)
+		}
+	}
+
+	if err := d.Set("secret_ids", secretIDs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceSecretMultiEnvRead(ctx, d, meta)
+}
+
+func resourceSecretMultiEnvDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	appID := d.Get("app_id").(string)
+
+	for env, id := range d.Get("secret_ids").(map[string]interface{}) {
+		err := client.DeleteSecret(ctx, appID, env, id.(string))
+		if err != nil && !isNotFound(err) {
+			return diag.Errorf("error deleting secret in environment %q: %s", env, err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// secretEnvironment is the desired value of a secret in one environment
+type secretEnvironment struct {
+	Value   string
+	Comment string
+}
+
+// expandSecretEnvironments converts environment blocks into a map keyed by
+// environment name
+func expandSecretEnvironments(set *schema.Set) (map[string]secretEnvironment, error) {
+	environments := make(map[string]secretEnvironment)
+	for _, raw := range set.List() {
+		m := raw.(map[string]interface{})
+
+		name := m["name"].(string)
+		if _, ok := environments[name]; ok {
+			return nil, fmt.Errorf("duplicate environment %q", name)
+		}
+
+		environments[name] = secretEnvironment{
+			Value:   m["value"].(string),
+			Comment: m["comment"].(string),
+		}
+	}
+
+	return environments, nil
+}
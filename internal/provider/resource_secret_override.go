@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceSecretOverride() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSecretOverrideCreate,
+		ReadContext:   resourceSecretOverrideRead,
+		UpdateContext: resourceSecretOverrideUpdate,
+		DeleteContext: resourceSecretOverrideDelete,
+
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the Phase App.",
+			},
+			"env": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The environment name.",
+			},
+			"path": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "/",
+				ForceNew:         true,
+				StateFunc:        normalizePathStateFunc,
+				DiffSuppressFunc: suppressEquivalentPath,
+				Description:      "The path of the secret.",
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The key of the existing secret to override.",
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The override value.",
+			},
+			"is_active": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the override is active.",
+			},
+		},
+	}
+}
+
+// findOverrideSecret returns the secret an override resource refers to, or nil
+// if it does not exist
+func findOverrideSecret(ctx context.Context, client *PhaseClient, d *schema.ResourceData) (*Secret, error) {
+	// The default path's diff is suppressed on create, leaving it empty
+	key := d.Get("key").(string)
+	path := rootedPath(d.Get("path").(string))
+
+	secrets, err := client.ReadSecret(ctx, d.Get("app_id").(string), d.Get("env").(string), key)
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+
+	for i := range secrets {
+		if secrets[i].Key == key && secrets[i].Path == path {
+			return &secrets[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// setSecretOverride writes the override for the authenticated user, leaving
+// the rest of the secret as last read, including its expiry and the
+// overrides of other members, unchanged
+func setSecretOverride(ctx context.Context, client *PhaseClient, d *schema.ResourceData, secret *Secret, override *SecretOverride) error {
+	update := Secret{
+		ID:        secret.ID,
+		Key:       secret.Key,
+		Value:     secret.Value,
+		Comment:   secret.Comment,
+		Path:      secret.Path,
+		Tags:      secret.Tags,
+		ExpiresAt: secret.ExpiresAt,
+		Override:  override,
+		Overrides: secret.Overrides,
+	}
+
+	_, err := client.UpdateSecret(ctx, d.Get("app_id").(string), d.Get("env").(string), update)
+	return err
+}
+
+func resourceSecretOverrideCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	secret, err := findOverrideSecret(ctx, client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if secret == nil {
+		return diag.Errorf("no secret found with key %q at path %q to override", d.Get("key").(string), rootedPath(d.Get("path").(string)))
+	}
+
+	err = setSecretOverride(ctx, client, d, secret, &SecretOverride{
+		Value:    d.Get("value").(string),
+		IsActive: d.Get("is_active").(bool),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(secret.ID)
+	return resourceSecretOverrideRead(ctx, d, meta)
+}
+
+func resourceSecretOverrideRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	secret, err := findOverrideSecret(ctx, client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if secret == nil || secret.Override == nil {
+		log.Printf("[WARN] Override for secret %s not found, removing from state", d.Get("key").(string))
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(secret.ID)
+	d.Set("path", secret.Path)
+	d.Set("value", secret.Override.Value)
+	d.Set("is_active", secret.Override.IsActive)
+
+	return nil
+}
+
+func resourceSecretOverrideUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	secret, err := findOverrideSecret(ctx, client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if secret == nil {
+		return diag.Errorf("secret %q no longer exists", d.Get("key").(string))
+	}
+
+	err = setSecretOverride(ctx, client, d, secret, &SecretOverride{
+		Value:    d.Get("value").(string),
+		IsActive: d.Get("is_active").(bool),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceSecretOverrideRead(ctx, d, meta)
+}
+
+// resourceSecretOverrideDelete deactivates and clears the override. The base
+// secret is left in place.
+func resourceSecretOverrideDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	secret, err := findOverrideSecret(ctx, client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if secret != nil {
+		err = setSecretOverride(ctx, client, d, secret, &SecretOverride{IsActive: false})
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to remove override: %w", err))
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestSecretOverrideKeepsSecret(t *testing.T) {
+	fake := newFakePhase(t)
+	memberOverride := SecretOverride{MemberID: "member", Value: "theirs", IsActive: true}
+	base := fake.add(Secret{
+		Key:       "DB_URL",
+		Value:     "base",
+		Path:      "/",
+		Tags:      []string{"db"},
+		ExpiresAt: "2030-01-01T00:00:00Z",
+		Overrides: []SecretOverride{memberOverride},
+	})
+	r := newTestResource(t, fake.client(), "phase_secret_override")
+
+	config := func(value string) cty.Value {
+		return r.config(map[string]cty.Value{
+			"app_id": cty.StringVal("app"),
+			"env":    cty.StringVal("Development"),
+			"key":    cty.StringVal("DB_URL"),
+			"value":  cty.StringVal(value),
+		})
+	}
+
+	check := func(step, wantOverride string) {
+		t.Helper()
+
+		secrets := fake.list()
+		if len(secrets) != 1 {
+			t.Fatalf("%s: %d secrets stored, want 1", step, len(secrets))
+		}
+		got := secrets[0]
+		if got.Value != base.Value || got.ExpiresAt != base.ExpiresAt || !slices.Equal(got.Tags, base.Tags) {
+			t.Errorf("%s: secret changed to value %q, expires_at %q, tags %v", step, got.Value, got.ExpiresAt, got.Tags)
+		}
+		if len(got.Overrides) != 1 || got.Overrides[0] != memberOverride {
+			t.Errorf("%s: other members' overrides changed to %+v", step, got.Overrides)
+		}
+		if got.Override == nil || got.Override.Value != wantOverride {
+			t.Errorf("%s: override = %+v, want value %q", step, got.Override, wantOverride)
+		}
+	}
+
+	state := r.create(config("mine"))
+	check("create", "mine")
+
+	state = r.update(state, config("changed"))
+	check("update", "changed")
+
+	requireNoErrors(t, r.destroy(state))
+	check("destroy", "")
+}
@@ -0,0 +1,278 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceSecretSync() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSecretSyncCreate,
+		ReadContext:   resourceSecretSyncRead,
+		UpdateContext: resourceSecretSyncUpdate,
+		DeleteContext: resourceSecretSyncDelete,
+
+		CustomizeDiff: resyncSecrets,
+
+		Schema: map[string]*schema.Schema{
+			"source_app_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the Phase App to copy secrets from.",
+			},
+			"source_env": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The environment to copy secrets from.",
+			},
+			"source_path": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "/",
+				StateFunc:        normalizePathStateFunc,
+				DiffSuppressFunc: suppressEquivalentPath,
+				Description:      "The path to copy secrets from.",
+			},
+			"destination_app_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the Phase App to copy secrets to.",
+			},
+			"destination_env": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The environment to copy secrets to.",
+			},
+			"destination_path": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "/",
+				ForceNew:         true,
+				StateFunc:        normalizePathStateFunc,
+				DiffSuppressFunc: suppressEquivalentPath,
+				Description:      "The path to copy secrets to.",
+			},
+			"keys": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "The keys of the secrets to copy. Other secrets in the destination are left alone.",
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validateSecretKey,
+				},
+			},
+			"out_of_sync_keys": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The keys whose destination value was missing or differed from the source when last read.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// secretSyncPlan is the set of changes needed to bring the destination in line
+// with the source
+type secretSyncPlan struct {
+	create []Secret
+	update []Secret
+	// missing lists keys that do not exist in the source
+	missing []string
+}
+
+// outOfSync returns the sorted keys that the plan would change
+func (p *secretSyncPlan) outOfSync() []string {
+	keys := append([]string{}, p.missing...)
+	for _, secret := range p.create {
+		keys = append(keys, secret.Key)
+	}
+	for _, secret := range p.update {
+		keys = append(keys, secret.Key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// planSecretSync compares the managed keys in the source and destination
+func planSecretSync(ctx context.Context, client *PhaseClient, d *schema.ResourceData) (*secretSyncPlan, error) {
+	// The default paths' diffs are suppressed on create, leaving them empty
+	sourcePath := rootedPath(d.Get("source_path").(string))
+	destinationPath := rootedPath(d.Get("destination_path").(string))
+
+	source, err := client.ListSecrets(ctx, d.Get("source_app_id").(string), d.Get("source_env").(string), sourcePath)
+	if err != nil && !isNotFound(err) {
+		return nil, fmt.Errorf("error reading source secrets: %w", err)
+	}
+
+	destination, err := client.ListSecrets(ctx, d.Get("destination_app_id").(string), d.Get("destination_env").(string), destinationPath)
+	if err != nil && !isNotFound(err) {
+		return nil, fmt.Errorf("error reading destination secrets: %w", err)
+	}
+
+	sourceByKey := make(map[string]Secret)
+	for _, secret := range source {
+		if secret.Path == sourcePath {
+			sourceByKey[secret.Key] = secret
+		}
+	}
+
+	destinationByKey := make(map[string]Secret)
+	for _, secret := range destination {
+		if secret.Path == destinationPath {
+			destinationByKey[secret.Key] = secret
+		}
+	}
+
+	plan := &secretSyncPlan{}
+	for _, raw := range d.Get("keys").(*schema.Set).List() {
+		key := raw.(string)
+
+		from, ok := sourceByKey[key]
+		if !ok {
+			plan.missing = append(plan.missing, key)
+			continue
+		}
+
+		to, ok := destinationByKey[key]
+		if !ok {
+			plan.create = append(plan.create, Secret{
+				Key:     key,
+				Value:   from.Value,
+				Comment: from.Comment,
+				Path:    destinationPath,
+				Tags:    from.Tags,
+			})
+			continue
+		}
+
+		// Only the value is synced, so comments and tags set in the
+		// destination are kept
+		if to.Value != from.Value {
+			to.Value = from.Value
+			plan.update = append(plan.update, to)
+		}
+	}
+
+	return plan, nil
+}
+
+// syncSecrets copies the managed keys from the source to the destination
+func syncSecrets(ctx context.Context, client *PhaseClient, d *schema.ResourceData) diag.Diagnostics {
+	plan, err := planSecretSync(ctx, client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(plan.missing) > 0 {
+		return diag.Errorf("secrets not found in source environment %q: %v", d.Get("source_env").(string), plan.missing)
+	}
+
+	appID := d.Get("destination_app_id").(string)
+	env := d.Get("destination_env").(string)
+
+	var diags diag.Diagnostics
+	if len(plan.create) > 0 {
+		_, err := client.CreateSecrets(ctx, appID, env, plan.create)
+		var batchErr *BatchError
+		if errors.As(err, &batchErr) {
+			diags = append(diags, batchDiagnostics(batchErr)...)
+		} else if err != nil {
+			return diag.Errorf("error creating destination secrets: %s", err)
+		}
+	}
+
+	if len(plan.update) > 0 {
+		_, err := client.UpdateSecrets(ctx, appID, env, plan.update)
+		var batchErr *BatchError
+		if errors.As(err, &batchErr) {
+			diags = append(diags, batchDiagnostics(batchErr)...)
+		} else if err != nil {
+			return append(diags, diag.Errorf("error updating destination secrets: %s", err)...)
+		}
+	}
+
+	return diags
+}
+
+func resourceSecretSyncCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	diags := syncSecrets(ctx, client, d)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s%s:%s/%s%s",
+		d.Get("source_app_id").(string),
+		d.Get("source_env").(string),
+		rootedPath(d.Get("source_path").(string)),
+		d.Get("destination_app_id").(string),
+		d.Get("destination_env").(string),
+		rootedPath(d.Get("destination_path").(string)),
+	))
+
+	return append(diags, resourceSecretSyncRead(ctx, d, meta)...)
+}
+
+func resourceSecretSyncRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	plan, err := planSecretSync(ctx, client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	outOfSync := plan.outOfSync()
+	if len(outOfSync) > 0 {
+		log.Printf("[WARN] Secrets %v in environment %q differ from environment %q", outOfSync, d.Get("destination_env").(string), d.Get("source_env").(string))
+	}
+
+	if err := d.Set("out_of_sync_keys", outOfSync); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceSecretSyncUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	diags := syncSecrets(ctx, client, d)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceSecretSyncRead(ctx, d, meta)...)
+}
+
+// resourceSecretSyncDelete only removes the resource from state. The secrets
+// already copied to the destination are left in place.
+func resourceSecretSyncDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+// resyncSecrets plans an update when the last read found the destination out
+// of sync with the source, so that the next apply copies the values again
+func resyncSecrets(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if outOfSync := d.Get("out_of_sync_keys").([]interface{}); len(outOfSync) > 0 {
+		return d.SetNew("out_of_sync_keys", []string{})
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestSecretSyncDefaultPaths(t *testing.T) {
+	fake := newFakePhase(t)
+	fake.addTo("Staging", Secret{Key: "DB_URL", Value: "postgres://", Path: "/"})
+	r := newTestResource(t, fake.client(), "phase_secret_sync")
+
+	state := r.create(r.config(map[string]cty.Value{
+		"source_app_id":      cty.StringVal("app"),
+		"source_env":         cty.StringVal("Staging"),
+		"destination_app_id": cty.StringVal("app"),
+		"destination_env":    cty.StringVal("Production"),
+		"keys":               cty.SetVal([]cty.Value{cty.StringVal("DB_URL")}),
+	}))
+
+	copied := fake.listIn("Production")
+	if len(copied) != 1 || copied[0].Key != "DB_URL" || copied[0].Path != "/" || copied[0].Value != "postgres://" {
+		t.Fatalf("Production has %+v, want DB_URL copied to /", copied)
+	}
+	if got, want := state.GetAttr("id").AsString(), "app/Staging/:app/Production/"; got != want {
+		t.Errorf("ID = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,373 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceSecrets() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSecretsCreate,
+		ReadContext:   resourceSecretsRead,
+		UpdateContext: resourceSecretsUpdate,
+		DeleteContext: resourceSecretsDelete,
+		CustomizeDiff: rejectPlaceholderSecrets,
+
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the Phase App.",
+			},
+			"env": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The environment name.",
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "/",
+				ForceNew:    true,
+				StateFunc:   normalizePathStateFunc,
+				Description: "The path the secrets are stored under.",
+			},
+			"secret": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "The secrets to manage. Each key may only appear once.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validateSecretKey,
+							Description:      "The secret key.",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "The secret value.",
+						},
+						"comment": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "A comment describing the secret.",
+						},
+						"tags": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "Tags to attach to the secret.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"secret_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "A map of secret keys to their Phase secret IDs.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceSecretsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	appID := d.Get("app_id").(string)
+	env := d.Get("env").(string)
+	path := normalizePath(d.Get("path").(string))
+
+	secrets, err := expandSecretsSet(d.Get("secret").(*schema.Set), path)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	createdSecrets, err := client.CreateSecrets(ctx, appID, env, secrets)
+	var batchErr *BatchError
+	if err != nil && !errors.As(err, &batchErr) {
+		return diag.FromErr(err)
+	}
+
+	// Record the secrets that were created even if others failed, so they
+	// are tracked in state rather than orphaned
+	secretIDs := make(map[string]interface{})
+	for _, secret := range createdSecrets {
+		secretIDs[secret.Key] = secret.ID
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s%s", appID, env, path))
+	if err := d.Set("secret_ids", secretIDs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Read back the secrets that were created, so state tracks only what
+	// Phase accepted and the failed ones are planned again after a refresh
+	if batchErr != nil {
+		return append(batchDiagnostics(batchErr), resourceSecretsRead(ctx, d, meta)...)
+	}
+
+	return resourceSecretsRead(ctx, d, meta)
+}
+
+func resourceSecretsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	appID := d.Get("app_id").(string)
+	env := d.Get("env").(string)
+	path := normalizePath(d.Get("path").(string))
+
+	// An empty environment means every managed secret was deleted
+	secrets, err := client.ReadSecret(ctx, appID, env, "")
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	remote := make(map[string]Secret)
+	for _, secret := range secrets {
+		if secret.Path == path {
+			remote[secret.Key] = secret
+		}
+	}
+
+	// Only reconcile the keys managed by this resource, dropping any that no
+	// longer exist so they are recreated on the next apply
+	var entries []interface{}
+	secretIDs := make(map[string]interface{})
+	for _, raw := range d.Get("secret").(*schema.Set).List() {
+		key := raw.(map[string]interface{})["key"].(string)
+
+		secret, ok := remote[key]
+		if !ok {
+			continue
+		}
+
+		entries = append(entries, map[string]interface{}{
+			"key":     secret.Key,
+			"value":   secret.Value,
+			"comment": secret.Comment,
+			"tags":    secret.Tags,
+		})
+		secretIDs[secret.Key] = secret.ID
+	}
+
+	if err := d.Set("secret", entries); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("secret_ids", secretIDs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceSecretsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	appID := d.Get("app_id").(string)
+	env := d.Get("env").(string)
+	path := normalizePath(d.Get("path").(string))
+
+	oldRaw, newRaw := d.GetChange("secret")
+	oldSet := oldRaw.(*schema.Set)
+	newSet := newRaw.(*schema.Set)
+
+	newSecrets, err := expandSecretsSet(newSet, path)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	secretIDs := d.Get("secret_ids").(map[string]interface{})
+
+	newKeys := make(map[string]bool)
+	var toCreate, toUpdate []Secret
+	for i, raw := range newSet.List() {
+		secret := newSecrets[i]
+		newKeys[secret.Key] = true
+
+		id, ok := secretIDs[secret.Key]
+		if !ok {
+			toCreate = append(toCreate, secret)
+			continue
+		}
+
+		// Unchanged entries hash identically in the old and new sets
+		if oldSet.Contains(raw) {
+			continue
+		}
+
+		secret.ID = id.(string)
+		toUpdate = append(toUpdate, secret)
+	}
+
+	var toDelete []string
+	for key, id := range secretIDs {
+		if !newKeys[key] {
+			toDelete = append(toDelete, id.(string))
+			delete(secretIDs, key)
+		}
+	}
+
+	if len(toDelete) > 0 {
+		if err := client.DeleteSecrets(ctx, appID, env, toDelete); err != nil {
+			return readBackSecrets(ctx, d, meta, oldSet, newSet, diag.FromErr(err))
+		}
+	}
+
+	var diags diag.Diagnostics
+
+	if len(toUpdate) > 0 {
+		_, err := client.UpdateSecrets(ctx, appID, env, toUpdate)
+		var batchErr *BatchError
+		if errors.As(err, &batchErr) {
+			diags = append(diags, batchDiagnostics(batchErr)...)
+		} else if err != nil {
+			return readBackSecrets(ctx, d, meta, oldSet, newSet, append(diags, diag.FromErr(err)...))
+		}
+	}
+
+	if len(toCreate) > 0 {
+		createdSecrets, err := client.CreateSecrets(ctx, appID, env, toCreate)
+		var batchErr *BatchError
+		if errors.As(err, &batchErr) {
+			diags = append(diags, batchDiagnostics(batchErr)...)
+		} else if err != nil {
+			return readBackSecrets(ctx, d, meta, oldSet, newSet, append(diags, diag.FromErr(err)...))
+		}
+		for _, secret := range createdSecrets {
+			secretIDs[secret.Key] = secret.ID
+		}
+	}
+
+	if diags.HasError() {
+		return readBackSecrets(ctx, d, meta, oldSet, newSet, diags)
+	}
+
+	if err := d.Set("secret_ids", secretIDs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceSecretsRead(ctx, d, meta)
+}
+
+// readBackSecrets refreshes state after a failed update, so that it tracks
+// only what Phase accepted and the failed changes are planned again. Keys from
+// both the old and new secret blocks are read, so a secret whose deletion
+// failed stays tracked in secret_ids.
+func readBackSecrets(ctx context.Context, d *schema.ResourceData, meta interface{}, oldSet, newSet *schema.Set, diags diag.Diagnostics) diag.Diagnostics {
+	entries := newSet.List()
+	keys := make(map[string]bool)
+	for _, raw := range entries {
+		keys[raw.(map[string]interface{})["key"].(string)] = true
+	}
+	for _, raw := range oldSet.List() {
+		if !keys[raw.(map[string]interface{})["key"].(string)] {
+			entries = append(entries, raw)
+		}
+	}
+
+	if err := d.Set("secret", entries); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	return append(diags, resourceSecretsRead(ctx, d, meta)...)
+}
+
+func resourceSecretsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	appID := d.Get("app_id").(string)
+	env := d.Get("env").(string)
+
+	var secretIDs []string
+	for _, id := range d.Get("secret_ids").(map[string]interface{}) {
+		secretIDs = append(secretIDs, id.(string))
+	}
+
+	if len(secretIDs) > 0 {
+		err := client.DeleteSecrets(ctx, appID, env, secretIDs)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// batchDiagnostics reports each secret that failed in a batch request as a
+// separate error
+func batchDiagnostics(batchErr *BatchError) diag.Diagnostics {
+	detail := func(reason string) string {
+		if batchErr.APIError != nil && batchErr.APIError.RequestID != "" {
+			return fmt.Sprintf("%s (request ID: %s)", reason, batchErr.APIError.RequestID)
+		}
+		return reason
+	}
+
+	var diags diag.Diagnostics
+	for _, failure := range batchErr.Failed {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("%s: %s", batchErr.Message, failure.Key),
+			Detail:   detail(failure.Error),
+		})
+	}
+	return diags
+}
+
+// rejectPlaceholderSecrets fails the plan when any secret value matches one of
+// the provider's placeholder patterns
+func rejectPlaceholderSecrets(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	client := meta.(*PhaseClient)
+	if len(client.PlaceholderPatterns) == 0 || !d.HasChange("secret") || !d.NewValueKnown("secret") {
+		return nil
+	}
+
+	for _, raw := range d.Get("secret").(*schema.Set).List() {
+		m := raw.(map[string]interface{})
+		if err := checkPlaceholderValue(client, m["key"].(string), m["value"].(string)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// expandSecretsSet converts secret blocks into secrets at the given path,
+// returned in the same order as the set's List
+func expandSecretsSet(set *schema.Set, path string) ([]Secret, error) {
+	seen := make(map[string]bool)
+	var secrets []Secret
+	for _, raw := range set.List() {
+		m := raw.(map[string]interface{})
+
+		key := m["key"].(string)
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate secret key %q", key)
+		}
+		seen[key] = true
+
+		secrets = append(secrets, Secret{
+			Key:     key,
+			Value:   m["value"].(string),
+			Comment: m["comment"].(string),
+			Path:    path,
+			Tags:    expandTags(m["tags"].(*schema.Set)),
+		})
+	}
+
+	return secrets, nil
+}
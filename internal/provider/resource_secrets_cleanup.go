@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceSecretsCleanup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSecretsCleanupCreate,
+		ReadContext:   schema.NoopContext,
+		DeleteContext: resourceSecretsCleanupDelete,
+
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the Phase App.",
+			},
+			"env": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The environment name.",
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				StateFunc:   normalizePathStateFunc,
+				Description: "Only delete secrets at this path. If not set, secrets at every path are deleted.",
+			},
+			"key_prefix": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				AtLeastOneOf: []string{"key_prefix", "tag"},
+				Description:  "Only delete secrets whose key starts with this prefix.",
+			},
+			"tag": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				AtLeastOneOf: []string{"key_prefix", "tag"},
+				Description:  "Only delete secrets with this tag.",
+			},
+			"confirm_destroy": {
+				Type:        schema.TypeBool,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Must be set to true to confirm that every matching secret should be deleted.",
+			},
+			"deleted_keys": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The keys of the secrets that were deleted.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceSecretsCleanupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	if !d.Get("confirm_destroy").(bool) {
+		return diag.Errorf("confirm_destroy must be set to true to delete secrets matching the filter")
+	}
+
+	appID := d.Get("app_id").(string)
+	env := d.Get("env").(string)
+	filter := SecretFilter{
+		Path:             normalizePath(d.Get("path").(string)),
+		KeyPrefix:        d.Get("key_prefix").(string),
+		Tag:              d.Get("tag").(string),
+		IncludeProtected: allowProtectedDestroy(),
+	}
+
+	deleted, skipped, err := client.DeleteSecretsByFilter(ctx, appID, env, filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	if len(skipped) > 0 {
+		skippedKeys := make([]string, 0, len(skipped))
+		for _, secret := range skipped {
+			skippedKeys = append(skippedKeys, secret.Key)
+		}
+		sort.Strings(skippedKeys)
+
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Skipped %d protected secret(s)", len(skipped)),
+			Detail:   fmt.Sprintf("prevent_destroy_on_server is set on %s, so they were not deleted. To delete them, set the %s environment variable to true for this run.", strings.Join(skippedKeys, ", "), AllowProtectedDestroyEnv),
+		})
+	}
+
+	deletedKeys := make([]string, 0, len(deleted))
+	for _, secret := range deleted {
+		deletedKeys = append(deletedKeys, secret.Key)
+	}
+	sort.Strings(deletedKeys)
+
+	d.SetId(fmt.Sprintf("%s-%s-%s-%s-%s", appID, env, filter.Path, filter.KeyPrefix, filter.Tag))
+	if err := d.Set("deleted_keys", deletedKeys); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	return diags
+}
+
+// resourceSecretsCleanupDelete only removes the resource from state. The
+// secrets were deleted when the resource was created.
+func resourceSecretsCleanupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
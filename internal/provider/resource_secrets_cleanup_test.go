@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+)
+
+func TestSecretsCleanupSkipsProtected(t *testing.T) {
+	t.Setenv(AllowProtectedDestroyEnv, "")
+
+	fake := newFakePhase(t)
+	secrets := newTestResource(t, fake.client(), "phase_secret")
+	cleanup := newTestResource(t, fake.client(), "phase_secrets_cleanup")
+
+	config := secretConfig(secrets, map[string]cty.Value{
+		"key":                       cty.StringVal("BILLING_KEY"),
+		"tags":                      cty.SetVal([]cty.Value{cty.StringVal("billing")}),
+		"prevent_destroy_on_server": cty.True,
+	})
+	state := secrets.create(config)
+	if tags := fake.list()[0].Tags; !slices.Equal(tags, []string{"billing", ProtectedTag}) {
+		t.Errorf("tags of a protected secret in Phase = %v, want billing and %s", tags, ProtectedTag)
+	}
+	plan := secrets.plan(secrets.read(state), config)
+	requireNoErrors(t, plan.diagnostics)
+	if plan.changed("tags") {
+		t.Errorf("the protection tag planned a change to tags")
+	}
+	fake.add(Secret{Key: "BILLING_URL", Path: "/"})
+
+	run := func() (cty.Value, []*tfprotov5.Diagnostic) {
+		t.Helper()
+		plan := cleanup.plan(cty.NullVal(cleanup.ty), cleanup.config(map[string]cty.Value{
+			"app_id":          cty.StringVal("app"),
+			"env":             cty.StringVal("Development"),
+			"key_prefix":      cty.StringVal("BILLING_"),
+			"confirm_destroy": cty.True,
+		}))
+		requireNoErrors(t, plan.diagnostics)
+		state, diags := cleanup.apply(plan)
+		requireNoErrors(t, diags)
+		return state, diags
+	}
+
+	result, diags := run()
+	if !hasWarning(diags, "Skipped 1 protected secret(s)") {
+		t.Errorf("cleanup matching a protected secret: no warning in %v", diags)
+	}
+	if deleted := result.GetAttr("deleted_keys"); deleted.LengthInt() != 1 || deleted.Index(cty.NumberIntVal(0)).AsString() != "BILLING_URL" {
+		t.Errorf("deleted_keys = %#v, want only BILLING_URL", deleted)
+	}
+	if remaining := fake.list(); len(remaining) != 1 || remaining[0].Key != "BILLING_KEY" {
+		t.Errorf("secrets stored after cleanup = %+v, want only the protected one", remaining)
+	}
+
+	// The override deletes protected secrets as well
+	t.Setenv(AllowProtectedDestroyEnv, "true")
+	result, diags = run()
+	if hasWarning(diags, "protected") {
+		t.Errorf("cleanup with %s set: unexpected warning in %v", AllowProtectedDestroyEnv, diags)
+	}
+	if remaining := fake.list(); len(remaining) != 0 {
+		t.Errorf("secrets stored after cleanup with %s set = %+v, want none", AllowProtectedDestroyEnv, remaining)
+	}
+}
+
+func TestProtectionTags(t *testing.T) {
+	tests := []struct {
+		tags      []string
+		protected bool
+		want      []string
+	}{
+		{tags: nil, protected: true, want: []string{ProtectedTag}},
+		{tags: []string{"z", "a"}, protected: true, want: []string{"a", ProtectedTag, "z"}},
+		{tags: []string{"a", ProtectedTag}, protected: true, want: []string{"a", ProtectedTag}},
+		{tags: []string{"a", ProtectedTag}, protected: false, want: []string{"a"}},
+		{tags: []string{"a"}, protected: false, want: []string{"a"}},
+	}
+
+	for _, tt := range tests {
+		if got := protectionTags(tt.tags, tt.protected); !slices.Equal(got, tt.want) {
+			t.Errorf("protectionTags(%v, %t) = %v, want %v", tt.tags, tt.protected, got, tt.want)
+		}
+	}
+}
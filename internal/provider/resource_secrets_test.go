@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func secretsConfig(r *testResource, values map[string]string) cty.Value {
+	var blocks []cty.Value
+	for key, value := range values {
+		blocks = append(blocks, cty.ObjectVal(map[string]cty.Value{
+			"key":     cty.StringVal(key),
+			"value":   cty.StringVal(value),
+			"comment": cty.NullVal(cty.String),
+			"tags":    cty.NullVal(cty.Set(cty.String)),
+		}))
+	}
+	return r.config(map[string]cty.Value{
+		"app_id": cty.StringVal("app"),
+		"env":    cty.StringVal("Development"),
+		"path":   cty.StringVal("/"),
+		"secret": cty.SetVal(blocks),
+	})
+}
+
+// stateIDs returns the keys tracked in secret_ids
+func stateIDs(state cty.Value) map[string]bool {
+	keys := make(map[string]bool)
+	for key := range state.GetAttr("secret_ids").AsValueMap() {
+		keys[key] = true
+	}
+	return keys
+}
+
+// stateValues returns the values of the secret blocks in state by key
+func stateValues(state cty.Value) map[string]string {
+	values := make(map[string]string)
+	for it := state.GetAttr("secret").ElementIterator(); it.Next(); {
+		_, block := it.Element()
+		values[block.GetAttr("key").AsString()] = block.GetAttr("value").AsString()
+	}
+	return values
+}
+
+func TestSecretsPartialFailure(t *testing.T) {
+	fake := newFakePhase(t)
+	r := newTestResource(t, fake.client(), "phase_secrets")
+
+	fake.reject["B"] = "invalid value"
+	config := secretsConfig(r, map[string]string{"A": "a", "B": "b"})
+	state, diags := r.apply(r.plan(cty.NullVal(r.ty), config))
+	if len(diags) != 1 {
+		t.Fatalf("create returned %d diagnostics, want one for B: %v", len(diags), diags)
+	}
+	if got := stateIDs(state); len(got) != 1 || !got["A"] {
+		t.Errorf("secret_ids after a partly failed create = %v, want only A", got)
+	}
+
+	// Once Phase accepts it, the failed secret is planned again
+	delete(fake.reject, "B")
+	state = r.read(state)
+	if got := stateValues(state); len(got) != 1 || got["A"] != "a" {
+		t.Errorf("state after a partly failed create = %v, want only A", got)
+	}
+	plan := r.plan(state, config)
+	requireNoErrors(t, plan.diagnostics)
+	if !plan.changed("secret") {
+		t.Fatalf("the secret that failed to create was not planned again")
+	}
+	state, diags = r.apply(plan)
+	requireNoErrors(t, diags)
+
+	fake.reject["A"] = "invalid value"
+	config = secretsConfig(r, map[string]string{"A": "changed", "B": "changed"})
+	state, diags = r.apply(r.plan(state, config))
+	if len(diags) != 1 {
+		t.Fatalf("update returned %d diagnostics, want one for A: %v", len(diags), diags)
+	}
+	if got := stateIDs(state); len(got) != 2 {
+		t.Errorf("secret_ids after a partly failed update = %v, want A and B", got)
+	}
+
+	delete(fake.reject, "A")
+	state = r.read(state)
+	if got := stateValues(state); got["A"] != "a" || got["B"] != "changed" {
+		t.Errorf("state after a partly failed update = %v, want A unchanged and B changed", got)
+	}
+	plan = r.plan(state, config)
+	requireNoErrors(t, plan.diagnostics)
+	if !plan.changed("secret") {
+		t.Errorf("the secret that failed to update was not planned again")
+	}
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceServiceToken() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceServiceTokenCreate,
+		ReadContext:   resourceServiceTokenRead,
+		DeleteContext: resourceServiceTokenDelete,
+
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the Phase App the token grants access to.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the service token.",
+			},
+			"environments": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Description: "The names of the environments the token grants access to.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The service token. It is only available when the token is created.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the service token was created.",
+			},
+		},
+	}
+}
+
+func resourceServiceTokenCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	appID := d.Get("app_id").(string)
+
+	var environments []string
+	for _, env := range d.Get("environments").(*schema.Set).List() {
+		environments = append(environments, env.(string))
+	}
+	sort.Strings(environments)
+
+	serviceToken, err := client.CreateServiceToken(ctx, appID, ServiceToken{
+		Name:         d.Get("name").(string),
+		Environments: environments,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(serviceToken.ID)
+	d.Set("token", serviceToken.Token)
+	d.Set("created_at", serviceToken.CreatedAt)
+
+	return resourceServiceTokenRead(ctx, d, meta)
+}
+
+func resourceServiceTokenRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	appID := d.Get("app_id").(string)
+
+	serviceTokens, err := client.ListServiceTokens(ctx, appID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, serviceToken := range serviceTokens {
+		if serviceToken.ID != d.Id() {
+			continue
+		}
+
+		// The token value is only returned on create, so it is kept from state
+		d.Set("name", serviceToken.Name)
+		d.Set("environments", serviceToken.Environments)
+		d.Set("created_at", serviceToken.CreatedAt)
+		return nil
+	}
+
+	log.Printf("[WARN] Service token %s not found, removing from state", d.Id())
+	d.SetId("")
+	return nil
+}
+
+func resourceServiceTokenDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PhaseClient)
+
+	appID := d.Get("app_id").(string)
+
+	err := client.DeleteServiceToken(ctx, appID, d.Id())
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// responseLimitTransport wraps an http.RoundTripper and fails reads of
+// response bodies larger than limit bytes, so a misbehaving server cannot
+// exhaust the provider's memory
+type responseLimitTransport struct {
+	base  http.RoundTripper
+	limit int64
+}
+
+// RoundTrip executes the request and limits the size of the response body
+func (t *responseLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.ContentLength > t.limit {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %s %s returned %d bytes, the limit is %d", ErrResponseTooLarge, req.Method, req.URL.Redacted(), resp.ContentLength, t.limit)
+	}
+
+	resp.Body = &limitedBody{
+		body:      resp.Body,
+		remaining: t.limit,
+		limit:     t.limit,
+	}
+	return resp, nil
+}
+
+// limitedBody is a response body that returns an error once more than limit
+// bytes have been read
+type limitedBody struct {
+	body      io.ReadCloser
+	remaining int64
+	limit     int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, fmt.Errorf("%w: the limit is %d bytes", ErrResponseTooLarge, b.limit)
+	}
+
+	// Read one byte past the limit to tell a body of exactly limit bytes
+	// apart from a larger one
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+
+	n, err := b.body.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return n + int(b.remaining), fmt.Errorf("%w: the limit is %d bytes", ErrResponseTooLarge, b.limit)
+	}
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	return b.body.Close()
+}
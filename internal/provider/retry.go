@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// retryTransport wraps an http.RoundTripper and retries requests that fail
+// with transient errors using jittered exponential backoff, or the wait given
+// by a Retry-After header capped at waitMax. Each attempt is limited to
+// timeout, if set.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	waitMin    time.Duration
+	waitMax    time.Duration
+	timeout    time.Duration
+}
+
+// retryableStatusCodes are the response codes that are retried for any method
+var retryableStatusCodes = map[int]bool{
+	http.StatusTooManyRequests:     true,
+	http.StatusInternalServerError: true,
+	http.StatusBadGateway:          true,
+	http.StatusServiceUnavailable:  true,
+	http.StatusGatewayTimeout:      true,
+}
+
+// RoundTrip executes the request, retrying it while the failure is retryable
+// and the retry budget has not been exhausted
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		resp, err := t.send(attemptReq)
+		if attempt >= t.maxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		// A request body can only be replayed if it can be obtained again
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt)
+
+		fields := map[string]interface{}{
+			"method":  req.Method,
+			"url":     req.URL.Redacted(),
+			"attempt": attempt + 1,
+		}
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				wait = min(retryAfter, t.waitMax)
+				fields["retry_after_ms"] = retryAfter.Milliseconds()
+			}
+
+			// Return the response rather than a context error if the
+			// request's deadline would pass before the retry is sent
+			if deadline, ok := req.Context().Deadline(); ok && time.Until(deadline) <= wait {
+				return resp, err
+			}
+
+			fields["status_code"] = resp.StatusCode
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		} else {
+			fields["error"] = err.Error()
+		}
+
+		fields["wait_ms"] = wait.Milliseconds()
+		tflog.Debug(req.Context(), "Retrying Phase API request", fields)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		attemptReq = req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq.Body = body
+		}
+	}
+}
+
+// send sends a single attempt of req, limited to t.timeout. The deadline
+// stays in place until the response body is closed, so that reading the body
+// is limited too.
+func (t *retryTransport) send(req *http.Request) (*http.Response, error) {
+	if t.timeout <= 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelBody cancels the context of the attempt that returned it once closed
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// backoff returns a jittered wait for the given attempt, doubling from waitMin
+// and capped at waitMax
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	wait := t.waitMin
+	for i := 0; i < attempt && wait < t.waitMax; i++ {
+		wait *= 2
+	}
+	if wait > t.waitMax {
+		wait = t.waitMax
+	}
+	if wait <= t.waitMin {
+		return wait
+	}
+
+	// Pick a random wait between waitMin and the computed backoff
+	return t.waitMin + time.Duration(rand.Int63n(int64(wait-t.waitMin)))
+}
+
+// parseRetryAfter parses a Retry-After header in either its delta-seconds or
+// HTTP-date form, returning the wait relative to now
+func parseRetryAfter(header string, now time.Time) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+
+	return 0, false
+}
+
+// shouldRetry reports whether a request should be attempted again. Connection
+// errors are only retried for idempotent reads, while retryable status codes
+// are retried for any method.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		if req.Context().Err() != nil || errors.Is(err, ErrResponseTooLarge) {
+			return false
+		}
+		return req.Method == http.MethodGet || req.Method == http.MethodHead
+	}
+
+	return retryableStatusCodes[resp.StatusCode]
+}
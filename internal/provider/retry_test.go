@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryAfterNearRequestTimeout(t *testing.T) {
+	var served int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&served, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		writeJSON(w, []Secret{})
+	}))
+	defer server.Close()
+
+	// Waiting out the Retry-After takes as long as the whole timeout, which
+	// only applies to each attempt
+	client, diags := configureProvider(t, map[string]interface{}{
+		"host":            server.URL,
+		"request_timeout": 1,
+		"rate_limit":      0,
+		"retry": []interface{}{map[string]interface{}{
+			"max_retries":    1,
+			"retry_wait_min": 0,
+			"retry_wait_max": 2,
+		}},
+	})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if _, err := client.ListSecrets(context.Background(), "app", "Development", "/"); err != nil {
+		t.Fatalf("request retried after Retry-After: %s", err)
+	}
+	if n := atomic.LoadInt32(&served); n != 2 {
+		t.Errorf("server received %d requests, want 2", n)
+	}
+}
+
+func TestRetryTimeoutPerAttempt(t *testing.T) {
+	var served int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&served, 1) == 1 {
+			// The first attempt hangs until it times out
+			<-r.Context().Done()
+			return
+		}
+		io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &retryTransport{
+		base:       http.DefaultTransport,
+		maxRetries: 1,
+		timeout:    100 * time.Millisecond,
+	}}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("request with a timed out first attempt: %s", err)
+	}
+	defer resp.Body.Close()
+
+	// The attempt's deadline covers reading the body, so it must not have
+	// been cancelled when the response was returned
+	body, err := io.ReadAll(resp.Body)
+	if err != nil || string(body) != "ok" {
+		t.Errorf("response body = %q, %v, want ok", body, err)
+	}
+	if n := atomic.LoadInt32(&served); n != 2 {
+		t.Errorf("server received %d requests, want 2", n)
+	}
+}
+
+func TestRetryGivesUpBeforeDeadline(t *testing.T) {
+	var served int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&served, 1)
+		w.Header().Set("Retry-After", "5")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &retryTransport{
+		base:       http.DefaultTransport,
+		maxRetries: 3,
+		waitMax:    10 * time.Second,
+	}}
+
+	// The Retry-After wait outlasts the caller's deadline, so the response is
+	// returned at once instead of a context error after waiting
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request with a deadline before Retry-After: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusServiceUnavailable)
+	}
+	if n := atomic.LoadInt32(&served); n != 1 {
+		t.Errorf("server received %d requests, want 1", n)
+	}
+}
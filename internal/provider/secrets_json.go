@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// flattenSecretJSON decodes a JSON secret value into out, keyed by the secret
+// key followed by the dotted path to each scalar, e.g. "SA_KEY.client_email"
+// or "HOSTS.0". Values that are not JSON objects or arrays are stored as-is
+// under the secret key.
+func flattenSecretJSON(key, value string, out map[string]string) {
+	decoder := json.NewDecoder(bytes.NewBufferString(value))
+	decoder.UseNumber()
+
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil || decoder.More() {
+		out[key] = value
+		return
+	}
+
+	switch decoded.(type) {
+	case map[string]interface{}, []interface{}:
+		flattenJSONValue(key, decoded, out)
+	default:
+		out[key] = value
+	}
+}
+
+func flattenJSONValue(prefix string, v interface{}, out map[string]string) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for field, fieldValue := range v {
+			flattenJSONValue(prefix+"."+field, fieldValue, out)
+		}
+	case []interface{}:
+		for i, item := range v {
+			flattenJSONValue(fmt.Sprintf("%s.%d", prefix, i), item, out)
+		}
+	case nil:
+		out[prefix] = ""
+	default:
+		out[prefix] = fmt.Sprint(v)
+	}
+}
+
+// encodeSecretsObject encodes secrets as a JSON object. With inferTypes,
+// values that are JSON numbers or booleans are encoded as such instead of as
+// strings.
+func encodeSecretsObject(secrets map[string]string, inferTypes bool) (string, error) {
+	object := make(map[string]interface{}, len(secrets))
+	for key, value := range secrets {
+		object[key] = value
+		if !inferTypes {
+			continue
+		}
+
+		switch value {
+		case "true":
+			object[key] = true
+		case "false":
+			object[key] = false
+		default:
+			var number json.Number
+			if err := json.Unmarshal([]byte(value), &number); err == nil && number.String() == value {
+				object[key] = number
+			}
+		}
+	}
+
+	encoded, err := json.Marshal(object)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// encodeSecretsMetadataObject encodes the metadata of secrets as a JSON object
+// keyed by the same keys as the secrets map. SDKv2 cannot store a map of
+// objects, so this is the only way to look up metadata by key.
+func encodeSecretsMetadataObject(secrets map[string]Secret) (string, error) {
+	object := make(map[string]interface{}, len(secrets))
+	for key, secret := range secrets {
+		object[key] = secretMetadata(secret)
+	}
+
+	encoded, err := json.Marshal(object)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
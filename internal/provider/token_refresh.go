@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// refreshToken reloads the token from phase_token_file after a request sent
+// with usedAuth was rejected. It reports whether the request should be sent
+// again, which is only the case if the token has changed since then.
+func (c *PhaseClient) refreshToken(req *http.Request, usedAuth string) (bool, error) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	// Another request may already have loaded a newer token
+	if c.authHeaderLocked() != usedAuth {
+		return true, nil
+	}
+
+	data, err := os.ReadFile(c.tokenFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to reload phase_token_file: %w", err)
+	}
+
+	// Never include the token itself in the error, as it is a credential
+	tokenType, token, err := extractTokenInfo(strings.TrimSpace(string(data)))
+	if err != nil {
+		return false, fmt.Errorf("failed to reload phase_token_file: %w", err)
+	}
+
+	if tokenType == c.TokenType && token == c.Token {
+		return false, nil
+	}
+
+	c.TokenType = tokenType
+	c.Token = token
+
+	tflog.Info(req.Context(), "Reloaded Phase token from phase_token_file after an unauthorized response", map[string]interface{}{
+		"token_type": tokenType,
+	})
+
+	return true, nil
+}
+
+// retryWithRefreshedToken sends req again with the current token after resp
+// was rejected as unauthorized. If the token has not changed or the request
+// cannot be replayed, resp is returned unchanged.
+func (c *PhaseClient) retryWithRefreshedToken(req *http.Request, resp *http.Response) (*http.Response, error) {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return resp, nil
+	}
+
+	refreshed, err := c.refreshToken(req, req.Header.Get("Authorization"))
+	if err != nil {
+		tflog.Warn(req.Context(), "Could not reload Phase token", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return resp, nil
+	}
+	if !refreshed {
+		return resp, nil
+	}
+
+	retryReq := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			tflog.Warn(req.Context(), "Could not replay request with the reloaded Phase token", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return resp, nil
+		}
+		retryReq.Body = body
+	}
+	retryReq.Header.Set("Authorization", c.authHeader())
+
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	return c.send(retryReq)
+}
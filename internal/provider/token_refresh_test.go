@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRetryWithRefreshedTokenBodyNotReplayable(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte(testToken("pss_service", 2)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	client := &PhaseClient{Token: "old", TokenType: "ServiceAccount", tokenFile: tokenFile}
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{}"))
+	req.Header.Set("Authorization", client.authHeader())
+	req.GetBody = func() (io.ReadCloser, error) {
+		return nil, errors.New("body already consumed")
+	}
+	resp := &http.Response{StatusCode: http.StatusUnauthorized, Body: io.NopCloser(strings.NewReader("unauthorized"))}
+
+	got, err := client.retryWithRefreshedToken(req, resp)
+	if err != nil {
+		t.Fatalf("retryWithRefreshedToken() error = %v, want the unauthorized response", err)
+	}
+	if got != resp {
+		t.Fatalf("retryWithRefreshedToken() returned another response, want the unauthorized one")
+	}
+
+	// The caller still owns the body, so it must not have been drained
+	body, _ := io.ReadAll(got.Body)
+	if string(body) != "unauthorized" {
+		t.Errorf("response body = %q, want it unread", body)
+	}
+}
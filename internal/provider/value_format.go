@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// ValueFormatURL requires an absolute URL with a scheme and host
+	ValueFormatURL = "url"
+
+	// ValueFormatJSON requires a valid JSON document
+	ValueFormatJSON = "json"
+
+	// ValueFormatBase64 requires standard base64 with padding
+	ValueFormatBase64 = "base64"
+
+	// ValueFormatUUID requires a UUID in its canonical hyphenated form
+	ValueFormatUUID = "uuid"
+)
+
+// uuidPattern matches a UUID in its canonical hyphenated form
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// checkValueFormat returns an error if value does not conform to format. The
+// value itself is never included in the error.
+func checkValueFormat(format, value string) error {
+	switch format {
+	case ValueFormatURL:
+		parsed, err := url.Parse(value)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return fmt.Errorf("not an absolute URL with a scheme and host")
+		}
+	case ValueFormatJSON:
+		if !json.Valid([]byte(value)) {
+			return fmt.Errorf("not valid JSON")
+		}
+	case ValueFormatBase64:
+		if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+			return fmt.Errorf("not valid base64")
+		}
+	case ValueFormatUUID:
+		if !uuidPattern.MatchString(value) {
+			return fmt.Errorf("not a UUID")
+		}
+	}
+	return nil
+}
+
+// validateValueFormat fails the plan when value or value_wo does not conform
+// to value_format. Values that are not known until apply are not checked.
+func validateValueFormat(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	format := d.Get("value_format").(string)
+	if format == "" {
+		return nil
+	}
+
+	key := d.Get("key").(string)
+
+	if (d.HasChange("value") || d.HasChange("value_format")) && d.NewValueKnown("value") {
+		if value := d.Get("value").(string); value != "" {
+			if err := checkValueFormat(format, value); err != nil {
+				return fmt.Errorf("the value of secret %s must be in %s format: %s", key, format, err)
+			}
+		}
+	}
+
+	raw := d.GetRawConfig().GetAttr("value_wo")
+	if raw.IsKnown() && !raw.IsNull() {
+		if err := checkValueFormat(format, raw.AsString()); err != nil {
+			return fmt.Errorf("the value of secret %s must be in %s format: %s", key, format, err)
+		}
+	}
+
+	return nil
+}
+
+// decodeBase64Value decodes a base64 secret value. The decoded value must be
+// valid UTF-8, as Terraform strings cannot hold arbitrary bytes. The value
+// itself is never included in the error.
+func decodeBase64Value(key, value string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil {
+		return "", fmt.Errorf("the value of secret %s is not valid base64", key)
+	}
+	if !utf8.Valid(decoded) {
+		return "", fmt.Errorf("the value of secret %s decodes to binary data that is not valid UTF-8", key)
+	}
+	return string(decoded), nil
+}